@@ -0,0 +1,16 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+# Always run from repo root
+cd "$(dirname "$0")"
+
+# Ensure build directory exists
+mkdir -p build
+
+# Site tooling: fetch, leaderboard, submit, encrypt-inputs, mock-server
+ENTRYPOINT="./tools/input"
+BINARY="./build/aoc"
+
+go build -o "$BINARY" "$ENTRYPOINT"
+
+"$BINARY" "$@"
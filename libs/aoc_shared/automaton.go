@@ -0,0 +1,140 @@
+package aocshared
+
+// AutomatonRule computes a cell's next state from its current state and its eight neighbours,
+// indexed by Direction. Neighbours outside the grid are the zero cell.
+type AutomatonRule[TCell comparable] func(cell TCell, neighbors [8]TCell, x, y int) TCell
+
+// Automaton steps a cellular automaton over two buffers: each generation reads the front grid
+// and writes the back one, then the two are swapped.
+type Automaton[TCell comparable] struct {
+	front *Grid[TCell]
+	back  *Grid[TCell]
+	rule  AutomatonRule[TCell]
+
+	generation int
+	changed    []int // cells that changed in the last generation, where front and back differ
+
+	// With tracking on, only cells next to a change are evaluated again.
+	trackActive bool
+	candidates  []int
+	queued      []int // generation+1 in which a cell was last queued, to skip duplicates
+}
+
+// AutomatonCreate takes over grid as the front buffer; read the current state through AutomatonGrid.
+func AutomatonCreate[TCell comparable](grid *Grid[TCell], rule AutomatonRule[TCell]) *Automaton[TCell] {
+	return &Automaton[TCell]{
+		front:   grid,
+		back:    GridClone(grid),
+		rule:    rule,
+		changed: make([]int, 0),
+	}
+}
+
+// AutomatonTrackActive evaluates only the cells around the last generation's changes from the next
+// step on. This only holds for rules that leave a cell alone when nothing around it changed.
+func AutomatonTrackActive[TCell comparable](automaton *Automaton[TCell]) {
+	automaton.trackActive = true
+	automaton.queued = make([]int, len(automaton.front.cells))
+
+	// Nothing is known about the first generation, so every cell starts out active.
+	automaton.candidates = make([]int, len(automaton.front.cells))
+	for i := range automaton.candidates {
+		automaton.candidates[i] = i
+	}
+}
+
+// AutomatonGrid returns the current generation. The grid is reused as a buffer by later steps.
+func AutomatonGrid[TCell comparable](automaton *Automaton[TCell]) *Grid[TCell] {
+	return automaton.front
+}
+
+func AutomatonGeneration[TCell comparable](automaton *Automaton[TCell]) int {
+	return automaton.generation
+}
+
+// AutomatonStep advances one generation and returns how many cells changed.
+func AutomatonStep[TCell comparable](automaton *Automaton[TCell]) int {
+	front, back := automaton.front, automaton.back
+
+	// The back buffer still holds the generation before last, which differs only where cells changed.
+	for _, i := range automaton.changed {
+		back.cells[i] = front.cells[i]
+	}
+
+	changed := automaton.changed[:0]
+	evaluate := func(i int) {
+		x, y := i%front.width, i/front.width
+		cell := front.cells[i]
+
+		next := automaton.rule(cell, front.Adjacencies(x, y), x, y)
+		back.cells[i] = next
+		if next != cell {
+			changed = append(changed, i)
+		}
+	}
+
+	if automaton.trackActive {
+		for _, i := range automaton.candidates {
+			evaluate(i)
+		}
+	} else {
+		for i := range front.cells {
+			evaluate(i)
+		}
+	}
+
+	automaton.front, automaton.back = back, front
+	automaton.changed = changed
+	automaton.generation++
+
+	if automaton.trackActive {
+		automaton.queueAroundChanges()
+	}
+
+	return len(changed)
+}
+
+// queueAroundChanges collects the changed cells and their neighbours as the next candidates.
+func (automaton *Automaton[TCell]) queueAroundChanges() {
+	grid := automaton.front
+	stamp := automaton.generation + 1
+
+	candidates := automaton.candidates[:0]
+	queue := func(i int) {
+		if automaton.queued[i] != stamp {
+			automaton.queued[i] = stamp
+			candidates = append(candidates, i)
+		}
+	}
+
+	for _, i := range automaton.changed {
+		queue(i)
+		for p := range grid.Neighbors(Point{X: i % grid.width, Y: i / grid.width}, Conn8) {
+			queue(gridIndex(grid, p.X, p.Y))
+		}
+	}
+
+	automaton.candidates = candidates
+}
+
+// AutomatonRun advances the given number of generations and returns how many cell changes they made in total.
+func AutomatonRun[TCell comparable](automaton *Automaton[TCell], generations int) int {
+	total := 0
+	for range generations {
+		total += AutomatonStep(automaton)
+	}
+	return total
+}
+
+// AutomatonRunUntilStable steps until a generation changes nothing and returns the total number of cell changes.
+// The final, unchanged generation is counted by AutomatonGeneration.
+func AutomatonRunUntilStable[TCell comparable](automaton *Automaton[TCell]) int {
+	total := 0
+	for {
+		changed := AutomatonStep(automaton)
+		if changed == 0 {
+			return total
+		}
+		total += changed
+	}
+}
@@ -0,0 +1,352 @@
+package aocshared
+
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+)
+
+// BitGrid is a dense boolean grid packed 64 cells to a word. Each row starts on a fresh word and
+// the bits past the width in its last word are always zero, so whole words can be counted and masked.
+type BitGrid struct {
+	words  []uint64
+	stride int // words per row
+
+	width  int
+	height int
+}
+
+var _ CellGrid[bool] = (*BitGrid)(nil)
+
+func BitGridCreate(width, height int) *BitGrid {
+	stride := (width + 63) / 64
+	return &BitGrid{
+		words:  make([]uint64, stride*height),
+		stride: stride,
+		width:  width,
+		height: height,
+	}
+}
+
+func BitGridFromGrid(grid *Grid[bool]) *BitGrid {
+	bitGrid := BitGridCreate(grid.width, grid.height)
+	for i, cell := range grid.cells {
+		if cell {
+			bitGrid.setBit(i%grid.width, i/grid.width, true)
+		}
+	}
+	return bitGrid
+}
+
+func BitGridToGrid(bitGrid *BitGrid) *Grid[bool] {
+	grid := &Grid[bool]{
+		cells:     make([]bool, bitGrid.width*bitGrid.height),
+		width:     bitGrid.width,
+		height:    bitGrid.height,
+		queuedOps: make([]func(), 0),
+	}
+	for i := range grid.cells {
+		grid.cells[i] = bitGrid.bit(i%grid.width, i/grid.width)
+	}
+	return grid
+}
+
+func BitGridClone(bitGrid *BitGrid) *BitGrid {
+	cloned := *bitGrid
+	cloned.words = append([]uint64(nil), bitGrid.words...)
+	return &cloned
+}
+
+func (grid *BitGrid) Width() int {
+	return grid.width
+}
+
+func (grid *BitGrid) Height() int {
+	return grid.height
+}
+
+func (grid *BitGrid) bit(x, y int) bool {
+	return grid.words[y*grid.stride+x/64]&(1<<(x%64)) != 0
+}
+
+func (grid *BitGrid) setBit(x, y int, on bool) {
+	index, mask := y*grid.stride+x/64, uint64(1)<<(x%64)
+	if on {
+		grid.words[index] |= mask
+	} else {
+		grid.words[index] &^= mask
+	}
+}
+
+func (grid *BitGrid) InBounds(x, y int) bool {
+	return x >= 0 && y >= 0 && x < grid.width && y < grid.height
+}
+
+func (grid *BitGrid) At(x, y int) (bool, error) {
+	if !grid.InBounds(x, y) {
+		return false, fmt.Errorf("invalid coords")
+	}
+	return grid.bit(x, y), nil
+}
+
+func (grid *BitGrid) Set(x, y int, cell bool) error {
+	if !grid.InBounds(x, y) {
+		return fmt.Errorf("invalid coords")
+	}
+	grid.setBit(x, y, cell)
+	return nil
+}
+
+func (grid *BitGrid) Bounds() (minPoint, maxPoint Point) {
+	return Point{}, Point{X: grid.width - 1, Y: grid.height - 1}
+}
+
+// Row returns the words of row y, cell x at bit x%64 of word x/64, backed by the grid's storage.
+// Writers must keep the bits past the width zero.
+func (grid *BitGrid) Row(y int) []uint64 {
+	start := y * grid.stride
+	return grid.words[start : start+grid.stride : start+grid.stride]
+}
+
+// Count returns the number of set cells.
+func (grid *BitGrid) Count() int {
+	count := 0
+	for _, word := range grid.words {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+// window returns the 64 cells of row y starting at column x as one word, reading zeros outside the grid.
+func (grid *BitGrid) window(x, y int) uint64 {
+	if y < 0 || y >= grid.height || x <= -64 || grid.stride == 0 {
+		return 0
+	}
+
+	row := grid.Row(y)
+	wordIndex, shift := x/64, x%64
+	if x < 0 {
+		// Left of the row, the window holds the start of the first word moved over by -x cells.
+		return row[0] << -x
+	}
+
+	var word uint64
+	if wordIndex < len(row) {
+		word = row[wordIndex] >> shift
+	}
+	if shift != 0 && wordIndex+1 < len(row) {
+		word |= row[wordIndex+1] << (64 - shift)
+	}
+	return word
+}
+
+// NeighborCount counts the set cells among the eight around (x, y) with one shifted window per row.
+func (grid *BitGrid) NeighborCount(x, y int) int {
+	const around, sides = 0b111, 0b101
+
+	return bits.OnesCount64(grid.window(x-1, y-1)&around) +
+		bits.OnesCount64(grid.window(x-1, y)&sides) +
+		bits.OnesCount64(grid.window(x-1, y+1)&around)
+}
+
+// All yields every cell in reading order.
+func (grid *BitGrid) All() iter.Seq2[Point, bool] {
+	return func(yield func(Point, bool) bool) {
+		for y := 0; y < grid.height; y++ {
+			for x := 0; x < grid.width; x++ {
+				if !yield(Point{X: x, Y: y}, grid.bit(x, y)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Neighbors yields the in-bounds neighbours of p, clockwise from north.
+func (grid *BitGrid) Neighbors(p Point, conn Connectivity) iter.Seq[Point] {
+	directions := orthogonalDirections
+	if conn == Conn8 {
+		directions = allDirections
+	}
+
+	return func(yield func(Point) bool) {
+		for _, d := range directions {
+			x, y := d.ApplyDelta(p.X, p.Y)
+			if grid.InBounds(x, y) && !yield(Point{X: x, Y: y}) {
+				return
+			}
+		}
+	}
+}
+
+func (grid *BitGrid) Adjacencies(x, y int) [8]bool {
+	return cellAdjacencies(grid, x, y)
+}
+
+func (grid *BitGrid) ForEach(exec func(cell bool, x, y int)) {
+	for p, cell := range grid.All() {
+		exec(cell, p.X, p.Y)
+	}
+}
+
+func (grid *BitGrid) Debug(cellFormatter func(cell bool) string) {
+	debugCells(grid, cellFormatter)
+}
+
+// BitShape is a shape as one mask word per row, ready to be tested against a BitGrid row by row.
+type BitShape struct {
+	rows   []uint64
+	width  int
+	height int
+}
+
+// BitShapeCreate packs a shape whose offsets, once normalized, fit within 64 columns.
+func BitShapeCreate(shape []ShapeOffset) (BitShape, error) {
+	if len(shape) == 0 {
+		return BitShape{}, fmt.Errorf("empty shape")
+	}
+
+	normalized := normalizeShape(shape)
+	width, height := 0, 0
+	for _, offset := range normalized {
+		width, height = max(width, offset.DX+1), max(height, offset.DY+1)
+	}
+	if width > 64 {
+		return BitShape{}, fmt.Errorf("shape is %d wide, at most 64 columns fit in a mask", width)
+	}
+
+	rows := make([]uint64, height)
+	for _, offset := range normalized {
+		rows[offset.DY] |= 1 << offset.DX
+	}
+
+	return BitShape{rows: rows, width: width, height: height}, nil
+}
+
+// BitGridFits reports whether the shape, with its top-left corner at (x, y), lies inside the grid on clear cells.
+func BitGridFits(grid *BitGrid, shape BitShape, x, y int) bool {
+	if x < 0 || y < 0 || x+shape.width > grid.width || y+shape.height > grid.height {
+		return false
+	}
+
+	for dy, mask := range shape.rows {
+		if grid.window(x, y+dy)&mask != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// BitGridPlace sets the shape's cells with its top-left corner at (x, y); the shape must be inside the grid.
+func BitGridPlace(grid *BitGrid, shape BitShape, x, y int) {
+	applyShapeMask(grid, shape, x, y, func(word *uint64, mask uint64) { *word |= mask })
+}
+
+// BitGridRemove clears the shape's cells, undoing BitGridPlace.
+func BitGridRemove(grid *BitGrid, shape BitShape, x, y int) {
+	applyShapeMask(grid, shape, x, y, func(word *uint64, mask uint64) { *word &^= mask })
+}
+
+func applyShapeMask(grid *BitGrid, shape BitShape, x, y int, apply func(word *uint64, mask uint64)) {
+	wordIndex, shift := x/64, x%64
+	for dy, mask := range shape.rows {
+		row := grid.Row(y + dy)
+		apply(&row[wordIndex], mask<<shift)
+		if shift != 0 && wordIndex+1 < len(row) {
+			apply(&row[wordIndex+1], mask>>(64-shift))
+		}
+	}
+}
+
+// BitGridCanFitShapes is GridCanFitShapes on a bit grid: shapes go only on clear cells and are left
+// set when they all fit. Every shape must fit within 64 columns.
+func BitGridCanFitShapes(grid *BitGrid, shapes map[int][]ShapeOffset, shapeCounts map[int]int, flags PlacementFlags) (bool, error) {
+	shapeTransformations, remainingShapes := planShapePlacement(shapes, shapeCounts, flags)
+	if len(remainingShapes) == 0 {
+		return true, nil
+	}
+
+	masks := make(map[int][]BitShape, len(shapeTransformations))
+	for shapeID, transformations := range shapeTransformations {
+		for _, transformed := range transformations {
+			mask, err := BitShapeCreate(transformed)
+			if err != nil {
+				return false, fmt.Errorf("shape %d: %w", shapeID, err)
+			}
+			masks[shapeID] = append(masks[shapeID], mask)
+		}
+	}
+
+	counts := make(map[int]int, len(shapeCounts))
+	for shapeID, count := range shapeCounts {
+		counts[shapeID] = count
+	}
+
+	return bitGridCanFitShapesDFS(grid, counts, masks, remainingShapes), nil
+}
+
+func bitGridCanFitShapesDFS(grid *BitGrid, shapeCounts map[int]int, masks map[int][]BitShape, remainingShapes []int) bool {
+	if len(remainingShapes) == 0 {
+		return true
+	}
+
+	shapeID := remainingShapes[0]
+	for _, shape := range masks[shapeID] {
+		for anchorY := 0; anchorY+shape.height <= grid.height; anchorY++ {
+			for anchorX := range bitGridAnchors(grid, shape, anchorY) {
+				BitGridPlace(grid, shape, anchorX, anchorY)
+
+				shapeCounts[shapeID]--
+				nextRemaining := remainingShapes
+				if shapeCounts[shapeID] == 0 {
+					nextRemaining = remainingShapes[1:]
+				}
+
+				if bitGridCanFitShapesDFS(grid, shapeCounts, masks, nextRemaining) {
+					return true
+				}
+
+				shapeCounts[shapeID]++
+				BitGridRemove(grid, shape, anchorX, anchorY)
+			}
+		}
+	}
+
+	return false
+}
+
+// bitGridAnchors yields, left to right, the columns where the shape fits with its top row on row y.
+// Placing and removing shapes at earlier anchors in between is fine as long as each is undone.
+func bitGridAnchors(grid *BitGrid, shape BitShape, y int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		if shape.width > grid.width {
+			return
+		}
+
+		if grid.stride != 1 {
+			for x := 0; x+shape.width <= grid.width; x++ {
+				if BitGridFits(grid, shape, x, y) && !yield(x) {
+					return
+				}
+			}
+			return
+		}
+
+		// With one word per row, every anchor is tested at once: bit x of blocked is set when
+		// some cell of the shape lands on a set cell with the shape anchored at column x.
+		var blocked uint64
+		for dy, mask := range shape.rows {
+			row := grid.words[y+dy]
+			for ; mask != 0; mask &= mask - 1 {
+				blocked |= row >> bits.TrailingZeros64(mask)
+			}
+		}
+
+		free := ^blocked & (1<<(grid.width-shape.width+1) - 1)
+		for ; free != 0; free &= free - 1 {
+			if !yield(bits.TrailingZeros64(free)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package aocshared
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Cycle describes a sequence of states that repeats: the state after Start+Length steps equals
+// the state after Start steps, and so on forever.
+type Cycle struct {
+	Start  int // steps taken before the first state inside the loop
+	Length int
+}
+
+// Index maps step n onto the earliest step with the same state.
+func (c Cycle) Index(n int) int {
+	if n < c.Start {
+		return n
+	}
+	return c.Start + (n-c.Start)%c.Length
+}
+
+// The finders below run step from initial until a state repeats. step must return the next state
+// without modifying its argument, and key must give equal keys exactly for equal states;
+// GridFingerprint provides one for grids.
+
+// CycleFind remembers the step at which every key was first seen, so it simulates each state once.
+func CycleFind[S any](initial S, step func(state S) S, key func(state S) uint64) Cycle {
+	seen := make(map[uint64]int)
+
+	state := initial
+	for n := 0; ; n++ {
+		k := key(state)
+		if first, ok := seen[k]; ok {
+			return Cycle{Start: first, Length: n - first}
+		}
+		seen[k] = n
+
+		state = step(state)
+	}
+}
+
+// CycleFindBrent uses Brent's algorithm: it keeps only two states alive but simulates the sequence
+// about three times over. Prefer it when states are too large to hash each one into a map.
+func CycleFindBrent[S any](initial S, step func(state S) S, key func(state S) uint64) Cycle {
+	// Find the cycle length by letting the hare run ahead in doubling windows from the tortoise.
+	power, length := 1, 1
+	tortoiseKey, hare := key(initial), step(initial)
+	for hareKey := key(hare); tortoiseKey != hareKey; hareKey = key(hare) {
+		if power == length {
+			tortoiseKey = hareKey
+			power *= 2
+			length = 0
+		}
+		hare = step(hare)
+		length++
+	}
+
+	// With the hare a full cycle ahead, both meet exactly where the cycle starts.
+	tortoise, hare := initial, initial
+	for range length {
+		hare = step(hare)
+	}
+
+	start := 0
+	for key(tortoise) != key(hare) {
+		tortoise, hare = step(tortoise), step(hare)
+		start++
+	}
+
+	return Cycle{Start: start, Length: length}
+}
+
+// CycleMetricAt returns metric of the state after n steps, simulating only until the sequence repeats.
+// Only metric values are kept, so a cheap metric such as a load or a count keeps memory small.
+func CycleMetricAt[S, M any](initial S, step func(state S) S, key func(state S) uint64, metric func(state S) M, n int) M {
+	seen := make(map[uint64]int)
+	metrics := make([]M, 0)
+
+	state := initial
+	for i := 0; ; i++ {
+		if i == n {
+			return metric(state)
+		}
+
+		k := key(state)
+		if first, ok := seen[k]; ok {
+			return metrics[Cycle{Start: first, Length: i - first}.Index(n)]
+		}
+		seen[k] = i
+		metrics = append(metrics, metric(state))
+
+		state = step(state)
+	}
+}
+
+// CycleStateAt returns the state after n steps, keeping every state up to the first repeat.
+func CycleStateAt[S any](initial S, step func(state S) S, key func(state S) uint64, n int) S {
+	return CycleMetricAt(initial, step, key, func(state S) S { return state }, n)
+}
+
+// GridFingerprint hashes the size and contents of a grid into a state key for the cycle finders.
+// The hash is XXH64 over a fixed encoding, so fingerprints stay the same across runs and can be
+// logged or cached. Sparse grids are hashed by their set cells, so setting a cell to the fill value
+// changes the fingerprint. Cells must be made of fixed-size values and strings; pointer, interface
+// and channel cells panic, since they have no encoding that is the same in every run.
+func GridFingerprint[TCell comparable](grid CellGrid[TCell]) uint64 {
+	buf := make([]byte, 0, 64)
+
+	minPoint, maxPoint := grid.Bounds()
+	buf = appendFingerprintPoint(buf, minPoint)
+	buf = appendFingerprintPoint(buf, maxPoint)
+
+	if dense, ok := grid.(*Grid[TCell]); ok {
+		for _, cell := range dense.cells {
+			buf = appendFingerprintCell(buf, cell)
+		}
+		return xxh64(buf)
+	}
+
+	for p, cell := range grid.All() {
+		buf = appendFingerprintPoint(buf, p)
+		buf = appendFingerprintCell(buf, cell)
+	}
+	return xxh64(buf)
+}
+
+func appendFingerprintPoint(buf []byte, p Point) []byte {
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(p.X))
+	return binary.LittleEndian.AppendUint64(buf, uint64(p.Y))
+}
+
+// appendFingerprintCell encodes a cell independently of the process: numbers and booleans by their
+// bytes, strings length-prefixed, and arrays and structs field by field.
+func appendFingerprintCell[TCell comparable](buf []byte, cell TCell) []byte {
+	switch c := any(cell).(type) {
+	case int:
+		return binary.LittleEndian.AppendUint64(buf, uint64(c))
+	case bool:
+		return appendFingerprintBool(buf, c)
+	case string:
+		return appendFingerprintString(buf, c)
+	}
+	return appendFingerprintValue(buf, reflect.ValueOf(cell))
+}
+
+func appendFingerprintValue(buf []byte, v reflect.Value) []byte {
+	switch v.Kind() {
+	case reflect.Bool:
+		return appendFingerprintBool(buf, v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.LittleEndian.AppendUint64(buf, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binary.LittleEndian.AppendUint64(buf, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return binary.LittleEndian.AppendUint64(buf, math.Float64bits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(real(c)))
+		return binary.LittleEndian.AppendUint64(buf, math.Float64bits(imag(c)))
+	case reflect.String:
+		return appendFingerprintString(buf, v.String())
+	case reflect.Array:
+		for i := range v.Len() {
+			buf = appendFingerprintValue(buf, v.Index(i))
+		}
+		return buf
+	case reflect.Struct:
+		for i := range v.NumField() {
+			buf = appendFingerprintValue(buf, v.Field(i))
+		}
+		return buf
+	}
+	panic(fmt.Sprintf("GridFingerprint: cannot fingerprint %s cells, only fixed-size values and strings", v.Type()))
+}
+
+func appendFingerprintBool(buf []byte, b bool) []byte {
+	if b {
+		return append(buf, 1)
+	}
+	return append(buf, 0)
+}
+
+func appendFingerprintString(buf []byte, s string) []byte {
+	buf = binary.LittleEndian.AppendUint64(buf, uint64(len(s)))
+	return append(buf, s...)
+}
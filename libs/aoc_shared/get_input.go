@@ -1,33 +1,118 @@
 package aocshared
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 )
 
-func GetInput(year, day int) string {
-	content, err := os.ReadFile("input.txt")
+// RepoRootEnv overrides repository root discovery.
+const RepoRootEnv = "AOC_ROOT"
+
+// RepoRootMarker can be placed at the repository root when no go.work is present.
+const RepoRootMarker = ".aoc-root"
+
+// ErrRepoRootNotFound is returned when neither the working directory nor this package sit inside the repository.
+var ErrRepoRootNotFound = errors.New("repository root not found (no go.work or " + RepoRootMarker + " in any parent directory)")
+
+// RepoRoot locates the repository: $AOC_ROOT, else the nearest parent of the working directory
+// holding go.work or the marker file, else the same search starting from this package's source.
+func RepoRoot() (string, error) {
+	if root := os.Getenv(RepoRootEnv); root != "" {
+		return root, nil
+	}
+
+	if cwd, err := os.Getwd(); err == nil {
+		if root, ok := findRepoRoot(cwd); ok {
+			return root, nil
+		}
+	}
+
+	if _, sourceFile, _, ok := runtime.Caller(0); ok {
+		if root, ok := findRepoRoot(filepath.Dir(sourceFile)); ok {
+			return root, nil
+		}
+	}
+
+	return "", ErrRepoRootNotFound
+}
+
+func findRepoRoot(start string) (string, bool) {
+	for dir := start; ; dir = filepath.Dir(dir) {
+		for _, marker := range []string{"go.work", RepoRootMarker} {
+			if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+				return dir, true
+			}
+		}
+
+		if filepath.Dir(dir) == dir {
+			return "", false
+		}
+	}
+}
+
+// PuzzleDir returns src/<year>/day<N> under the repository root.
+func PuzzleDir(year, day int) (string, error) {
+	root, err := RepoRoot()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(root, "src", fmt.Sprintf("%d", year), fmt.Sprintf("day%d", day)), nil
+}
+
+func GetInput(year, day int) (string, error) {
+	content, err := GetInputRaw(year, day)
+	if err != nil {
+		return "", err
+	}
+
+	return InputNormalize(content), nil
+}
+
+// GetInputRaw returns the input bytes exactly as they were downloaded, decrypting them if needed.
+func GetInputRaw(year, day int) ([]byte, error) {
+	return readPuzzleFile(year, day, "input.txt")
+}
+
+func GetTestInput(year, day int) (string, error) {
+	content, err := readPuzzleFile(year, day, "test_input.txt")
 	if err != nil {
-		panic(err)
+		return "", err
 	}
 
-	return string(content)
+	return InputNormalize(content), nil
 }
 
-func GetTestInput(year, day int) string {
-	content, err := os.ReadFile("test_input.txt")
+func GetTestInputN(year, day, n int) (string, error) {
+	content, err := readPuzzleFile(year, day, fmt.Sprintf("test_input_%d.txt", n))
 	if err != nil {
-		panic(err)
+		return "", err
 	}
 
-	return string(content)
+	return InputNormalize(content), nil
 }
 
-func GetTestInputN(year, day, n int) string {
-	content, err := os.ReadFile(fmt.Sprintf("test_input_%d.txt", n))
+func readPuzzleFile(year, day int, name string) ([]byte, error) {
+	dir, err := PuzzleDir(year, day)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := InputReadFile(filepath.Join(dir, name))
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("failed to read %s for %d day %d: %w", name, year, day, err)
 	}
 
-	return string(content)
+	return content, nil
+}
+
+// InputNormalize converts line endings to '\n' and drops trailing line breaks.
+// Whitespace inside and at either end of a line is kept, as some layouts depend on it.
+func InputNormalize(content []byte) string {
+	normalized := strings.ReplaceAll(string(content), "\r\n", "\n")
+	return strings.TrimRight(normalized, "\n")
 }
@@ -1,3 +1,8 @@
 module aoc_shared
 
 go 1.25
+
+require (
+	github.com/mattn/go-isatty v0.0.20
+	golang.org/x/sys v0.38.0
+)
@@ -2,17 +2,54 @@ package aocshared
 
 import (
 	"fmt"
+	"iter"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
 )
 
 type Grid[TCell any] struct {
-	rows [][]TCell
+	cells []TCell // row-major, cell (x, y) at y*width + x
 
 	queuedOps []func()
 
-	sizeX int
-	sizeY int
+	width  int
+	height int
+
+	wrap WrapMode
+}
+
+// WrapMode selects the axes along which a grid wraps around, joining opposite edges.
+type WrapMode int
+
+const (
+	WrapNone WrapMode = 0
+	WrapX    WrapMode = 1
+	WrapY    WrapMode = 2
+	WrapBoth WrapMode = WrapX | WrapY
+)
+
+// WrapIndex maps any value onto [0, size), wrapping negative values from the top: WrapIndex(-1, 100) is 99.
+func WrapIndex(value, size int) int {
+	remainder := value % size
+	if remainder < 0 {
+		remainder += size
+	}
+	return remainder
+}
+
+// CellGrid is what Grid and SparseGrid have in common, so helpers can take either.
+type CellGrid[TCell any] interface {
+	At(x, y int) (TCell, error)
+	Set(x, y int, cell TCell) error
+	InBounds(x, y int) bool
+	Bounds() (minPoint, maxPoint Point)
+	All() iter.Seq2[Point, TCell]
+	Neighbors(p Point, conn Connectivity) iter.Seq[Point]
+	Adjacencies(x, y int) [8]TCell
+	ForEach(exec func(cell TCell, x, y int))
+	Debug(cellFormatter func(cell TCell) string)
 }
 
 type Direction int
@@ -44,6 +81,18 @@ func (d Direction) ApplyDelta(posX, posY int) (x, y int) {
 	return posX + DirDelta[d].DX, posY + DirDelta[d].DY
 }
 
+// ApplyDeltaWrap steps like ApplyDelta, wrapping the result onto a width x height area along the axes in mode.
+func (d Direction) ApplyDeltaWrap(posX, posY, width, height int, mode WrapMode) (x, y int) {
+	x, y = d.ApplyDelta(posX, posY)
+	if mode&WrapX != 0 {
+		x = WrapIndex(x, width)
+	}
+	if mode&WrapY != 0 {
+		y = WrapIndex(y, height)
+	}
+	return x, y
+}
+
 func (d Direction) Delta() (dx, dy int) {
 	return DirDelta[d].DX, DirDelta[d].DY
 }
@@ -70,29 +119,36 @@ func GridCreate[TCell any](rows [][]TCell) *Grid[TCell] {
 		panic("grid must have at least one row")
 	}
 
-	sizeY := len(rows)
-	sizeX := len(rows[0])
+	height := len(rows)
+	width := len(rows[0])
+
+	// Rows are copied into one slice; a row shorter than the first leaves zero cells behind it.
+	cells := make([]TCell, width*height)
+	for y, row := range rows {
+		copy(cells[y*width:(y+1)*width], row)
+	}
 
 	return &Grid[TCell]{
-		rows:      rows,
-		sizeX:     sizeX,
-		sizeY:     sizeY,
+		cells:     cells,
+		width:     width,
+		height:    height,
 		queuedOps: make([]func(), 0),
 	}
 }
 
+func gridIndex[TCell any](grid *Grid[TCell], x, y int) int {
+	return y*grid.width + x
+}
+
 func GridClone[TCell any](grid *Grid[TCell]) *Grid[TCell] {
 	clonedGrid := &Grid[TCell]{
-		sizeX: grid.sizeX,
-		sizeY: grid.sizeY,
+		cells:  make([]TCell, len(grid.cells)),
+		width:  grid.width,
+		height: grid.height,
+		wrap:   grid.wrap,
 	}
 
-	clonedGrid.rows = make([][]TCell, grid.sizeY)
-
-	for y := 0; y < grid.sizeY; y++ {
-		clonedGrid.rows[y] = make([]TCell, grid.sizeX)
-		copy(clonedGrid.rows[y], grid.rows[y])
-	}
+	copy(clonedGrid.cells, grid.cells)
 
 	clonedGrid.queuedOps = make([]func(), len(grid.queuedOps))
 	copy(clonedGrid.queuedOps, grid.queuedOps)
@@ -112,62 +168,266 @@ func GridQueuedOpsApply[TCell any](grid *Grid[TCell]) {
 	grid.queuedOps = []func(){}
 }
 
-func GridGetAt[TCell any](grid Grid[TCell], x, y int) (TCell, error) {
-	if x >= grid.sizeX || y >= grid.sizeY || x < 0 || y < 0 {
+// Connectivity selects which neighbours count as adjacent.
+type Connectivity int
+
+const (
+	Conn4 Connectivity = iota // orthogonal: N, E, S, W
+	Conn8                     // orthogonal and diagonal
+)
+
+var orthogonalDirections = []Direction{N, E, S, W}
+var allDirections = []Direction{N, NE, E, SE, S, SW, W, NW}
+
+func (grid *Grid[TCell]) Width() int {
+	return grid.width
+}
+
+func (grid *Grid[TCell]) Height() int {
+	return grid.height
+}
+
+// SetWrap makes the grid wrap around along the given axes. Coordinates on a wrapping axis are never out of bounds.
+func (grid *Grid[TCell]) SetWrap(mode WrapMode) {
+	grid.wrap = mode
+}
+
+func (grid *Grid[TCell]) Wrap() WrapMode {
+	return grid.wrap
+}
+
+// normalize applies the wrap mode and reports whether the resulting coordinates are inside the grid.
+func (grid *Grid[TCell]) normalize(x, y int) (int, int, bool) {
+	if grid.wrap&WrapX != 0 && grid.width > 0 {
+		x = WrapIndex(x, grid.width)
+	}
+	if grid.wrap&WrapY != 0 && grid.height > 0 {
+		y = WrapIndex(y, grid.height)
+	}
+
+	return x, y, x >= 0 && y >= 0 && x < grid.width && y < grid.height
+}
+
+func (grid *Grid[TCell]) InBounds(x, y int) bool {
+	_, _, ok := grid.normalize(x, y)
+	return ok
+}
+
+func (grid *Grid[TCell]) At(x, y int) (TCell, error) {
+	x, y, ok := grid.normalize(x, y)
+	if !ok {
 		var zero TCell
 		return zero, fmt.Errorf("invalid coords")
 	}
 
-	return grid.rows[y][x], nil
+	return grid.cells[gridIndex(grid, x, y)], nil
 }
 
-func GridSetAt[TCell any](grid *Grid[TCell], x, y int, cell TCell) error {
-	if x >= grid.sizeX || y >= grid.sizeY || x < 0 || y < 0 {
+func (grid *Grid[TCell]) Set(x, y int, cell TCell) error {
+	x, y, ok := grid.normalize(x, y)
+	if !ok {
 		return fmt.Errorf("invalid coords")
 	}
 
-	grid.rows[y][x] = cell
+	grid.cells[gridIndex(grid, x, y)] = cell
 
 	return nil
 }
 
-func GridGetAdjacencies[TCell any](grid Grid[TCell], x, y int) [8]TCell {
+// All yields every cell in reading order.
+func (grid *Grid[TCell]) All() iter.Seq2[Point, TCell] {
+	return func(yield func(Point, TCell) bool) {
+		for i, cell := range grid.cells {
+			if !yield(Point{X: i % grid.width, Y: i / grid.width}, cell) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors yields the in-bounds neighbours of p, clockwise from north.
+func (grid *Grid[TCell]) Neighbors(p Point, conn Connectivity) iter.Seq[Point] {
+	directions := orthogonalDirections
+	if conn == Conn8 {
+		directions = allDirections
+	}
+
+	return func(yield func(Point) bool) {
+		for _, d := range directions {
+			x, y, ok := grid.normalize(d.ApplyDelta(p.X, p.Y))
+			if ok && !yield(Point{X: x, Y: y}) {
+				return
+			}
+		}
+	}
+}
+
+// Ray yields the cells seen looking from p in direction d, excluding p itself.
+// It stops at the edge, or on a wrapping grid once it comes back around to p.
+func (grid *Grid[TCell]) Ray(p Point, d Direction) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		startX, startY, _ := grid.normalize(p.X, p.Y)
+
+		x, y := startX, startY
+		for {
+			var ok bool
+			x, y, ok = grid.normalize(d.ApplyDelta(x, y))
+			if !ok || (x == startX && y == startY) || !yield(Point{X: x, Y: y}) {
+				return
+			}
+		}
+	}
+}
+
+// Adjacencies returns the eight surrounding cells indexed by Direction; out of bounds ones are the zero cell.
+func (grid *Grid[TCell]) Adjacencies(x, y int) [8]TCell {
+	// Interior cells, the vast majority, need neither bounds checks nor wrapping.
+	if x > 0 && y > 0 && x < grid.width-1 && y < grid.height-1 {
+		i, w := gridIndex(grid, x, y), grid.width
+		cells := grid.cells
+		return [8]TCell{
+			N: cells[i-w], NE: cells[i-w+1], E: cells[i+1], SE: cells[i+w+1],
+			S: cells[i+w], SW: cells[i+w-1], W: cells[i-1], NW: cells[i-w-1],
+		}
+	}
+
+	fixedAdjacencies := [8]TCell{}
+	for d, delta := range DirDelta {
+		if nx, ny, ok := grid.normalize(x+delta.DX, y+delta.DY); ok {
+			fixedAdjacencies[d] = grid.cells[gridIndex(grid, nx, ny)]
+		}
+	}
+	return fixedAdjacencies
+}
+
+func cellAdjacencies[TCell any](grid CellGrid[TCell], x, y int) [8]TCell {
 	fixedAdjacencies := [8]TCell{}
 	for d := Direction(0); d < 8; d++ {
 		delta := DirDelta[d]
-		if cell, err := GridGetAt(grid, x+delta.DX, y+delta.DY); err == nil {
+		if cell, err := grid.At(x+delta.DX, y+delta.DY); err == nil {
 			fixedAdjacencies[d] = cell
 		}
 	}
 	return fixedAdjacencies
 }
 
-func GridForEach[TCell any](grid Grid[TCell], exec func(cell TCell, x, y int)) {
-	for y, row := range grid.rows {
-		for x, cell := range row {
-			exec(cell, x, y)
+func (grid *Grid[TCell]) ForEach(exec func(cell TCell, x, y int)) {
+	for i, cell := range grid.cells {
+		exec(cell, i%grid.width, i/grid.width)
+	}
+}
+
+func (grid *Grid[TCell]) Debug(cellFormatter func(cell TCell) string) {
+	debugCells(grid, cellFormatter)
+}
+
+// Bounds returns the top-left and bottom-right cells.
+func (grid *Grid[TCell]) Bounds() (minPoint, maxPoint Point) {
+	return Point{}, Point{X: grid.width - 1, Y: grid.height - 1}
+}
+
+// Row returns row y backed by the grid's storage: writes to it change the grid.
+// On a grid that wraps vertically, y wraps like it does for At.
+func (grid *Grid[TCell]) Row(y int) ([]TCell, error) {
+	_, y, _ = grid.normalize(0, y)
+	if y < 0 || y >= grid.height {
+		return nil, fmt.Errorf("invalid coords")
+	}
+
+	start := gridIndex(grid, 0, y)
+	return grid.cells[start : start+grid.width : start+grid.width], nil
+}
+
+// Col copies column x top to bottom. On a grid that wraps horizontally, x wraps like it does for At.
+func (grid *Grid[TCell]) Col(x int) ([]TCell, error) {
+	x, _, _ = grid.normalize(x, 0)
+	if x < 0 || x >= grid.width {
+		return nil, fmt.Errorf("invalid coords")
+	}
+
+	col := make([]TCell, grid.height)
+	for y := range col {
+		col[y] = grid.cells[gridIndex(grid, x, y)]
+	}
+	return col, nil
+}
+
+// Rows yields every row top to bottom, backed by the grid's storage like Row.
+func (grid *Grid[TCell]) Rows() iter.Seq2[int, []TCell] {
+	return func(yield func(int, []TCell) bool) {
+		for y := 0; y < grid.height; y++ {
+			row, _ := grid.Row(y)
+			if !yield(y, row) {
+				return
+			}
 		}
 	}
 }
 
+// Cols yields a copy of every column, left to right.
+func (grid *Grid[TCell]) Cols() iter.Seq2[int, []TCell] {
+	return func(yield func(int, []TCell) bool) {
+		for x := 0; x < grid.width; x++ {
+			col, _ := grid.Col(x)
+			if !yield(x, col) {
+				return
+			}
+		}
+	}
+}
+
+// GridCount counts the cells of either grid kind that match.
+func GridCount[TCell any](grid CellGrid[TCell], match func(cell TCell) bool) int {
+	count := 0
+	for _, cell := range grid.All() {
+		if match(cell) {
+			count++
+		}
+	}
+	return count
+}
+
+func GridGetAt[TCell any](grid Grid[TCell], x, y int) (TCell, error) {
+	return grid.At(x, y)
+}
+
+func GridSetAt[TCell any](grid *Grid[TCell], x, y int, cell TCell) error {
+	return grid.Set(x, y, cell)
+}
+
+func GridGetAdjacencies[TCell any](grid Grid[TCell], x, y int) [8]TCell {
+	return grid.Adjacencies(x, y)
+}
+
+func GridForEach[TCell any](grid Grid[TCell], exec func(cell TCell, x, y int)) {
+	grid.ForEach(exec)
+}
+
 func GridDebug[TCell any](grid Grid[TCell], cellFormatter func(cell TCell) string) {
-	if grid.sizeY == 0 {
+	grid.Debug(cellFormatter)
+}
+
+// debugCells prints the bounding box of any grid, aligned per column.
+func debugCells[TCell any](grid CellGrid[TCell], cellFormatter func(cell TCell) string) {
+	minPoint, maxPoint := grid.Bounds()
+	if maxPoint.X < minPoint.X || maxPoint.Y < minPoint.Y {
 		fmt.Println("(empty grid)")
 		return
 	}
 
-	strRows := mapRowsToStrings(grid, cellFormatter)
+	strRows := mapRowsToStrings(grid, minPoint, maxPoint, cellFormatter)
 	widths := getColWidths(strRows)
 
 	printGridAligned(strRows, widths)
 }
 
-func mapRowsToStrings[TCell any](grid Grid[TCell], cellFormatter func(cell TCell) string) [][]string {
-	strRows := make([][]string, grid.sizeY)
-	for y := 0; y < grid.sizeY; y++ {
-		strRows[y] = make([]string, grid.sizeX)
-		for x := 0; x < grid.sizeX; x++ {
-			strRows[y][x] = cellFormatter(grid.rows[y][x])
+func mapRowsToStrings[TCell any](grid CellGrid[TCell], minPoint, maxPoint Point, cellFormatter func(cell TCell) string) [][]string {
+	strRows := make([][]string, maxPoint.Y-minPoint.Y+1)
+	for y := range strRows {
+		strRows[y] = make([]string, maxPoint.X-minPoint.X+1)
+		for x := range strRows[y] {
+			cell, _ := grid.At(minPoint.X+x, minPoint.Y+y)
+			strRows[y][x] = cellFormatter(cell)
 		}
 	}
 	return strRows
@@ -215,19 +475,19 @@ func GridPlace[TCell any](grid *Grid[TCell], shape []ShapeOffset, value TCell, i
 		return false
 	}
 
-	for anchorY := 0; anchorY < grid.sizeY; anchorY++ {
-		for anchorX := 0; anchorX < grid.sizeX; anchorX++ {
+	for anchorY := 0; anchorY < grid.height; anchorY++ {
+		for anchorX := 0; anchorX < grid.width; anchorX++ {
 			allValid := true
 			for _, offset := range shape {
 				posX := anchorX + offset.DX
 				posY := anchorY + offset.DY
 
-				if posX < 0 || posX >= grid.sizeX || posY < 0 || posY >= grid.sizeY {
+				if posX < 0 || posX >= grid.width || posY < 0 || posY >= grid.height {
 					allValid = false
 					break
 				}
 
-				cell, err := GridGetAt(*grid, posX, posY)
+				cell, err := grid.At(posX, posY)
 				if err != nil || !isSuitable(cell, posX, posY) {
 					allValid = false
 					break
@@ -238,7 +498,7 @@ func GridPlace[TCell any](grid *Grid[TCell], shape []ShapeOffset, value TCell, i
 				for _, offset := range shape {
 					posX := anchorX + offset.DX
 					posY := anchorY + offset.DY
-					grid.rows[posY][posX] = value
+					grid.cells[gridIndex(grid, posX, posY)] = value
 				}
 				return true
 			}
@@ -375,12 +635,12 @@ func canPlaceShapeAt[TCell any](grid *Grid[TCell], shape []ShapeOffset, anchorX,
 	firstOffset := shape[0]
 	firstX := anchorX + firstOffset.DX
 	firstY := anchorY + firstOffset.DY
-	if firstX < 0 || firstX >= grid.sizeX || firstY < 0 || firstY >= grid.sizeY {
+	if firstX < 0 || firstX >= grid.width || firstY < 0 || firstY >= grid.height {
 		return false
 	}
 
 	// Direct array access for better performance
-	if !isSuitable(grid.rows[firstY][firstX], firstX, firstY) {
+	if !isSuitable(grid.cells[gridIndex(grid, firstX, firstY)], firstX, firstY) {
 		return false
 	}
 
@@ -390,11 +650,11 @@ func canPlaceShapeAt[TCell any](grid *Grid[TCell], shape []ShapeOffset, anchorX,
 		posX := anchorX + offset.DX
 		posY := anchorY + offset.DY
 
-		if posX < 0 || posX >= grid.sizeX || posY < 0 || posY >= grid.sizeY {
+		if posX < 0 || posX >= grid.width || posY < 0 || posY >= grid.height {
 			return false
 		}
 
-		if !isSuitable(grid.rows[posY][posX], posX, posY) {
+		if !isSuitable(grid.cells[gridIndex(grid, posX, posY)], posX, posY) {
 			return false
 		}
 	}
@@ -405,7 +665,7 @@ func placeShapeAt[TCell any](grid *Grid[TCell], shape []ShapeOffset, anchorX, an
 	for _, offset := range shape {
 		posX := anchorX + offset.DX
 		posY := anchorY + offset.DY
-		grid.rows[posY][posX] = value
+		grid.cells[gridIndex(grid, posX, posY)] = value
 	}
 }
 
@@ -423,14 +683,14 @@ func savePlacementState[TCell any](grid *Grid[TCell], shape []ShapeOffset, ancho
 		posX := anchorX + offset.DX
 		posY := anchorY + offset.DY
 		state.positions[i] = [2]int{posX, posY}
-		state.values[i] = grid.rows[posY][posX]
+		state.values[i] = grid.cells[gridIndex(grid, posX, posY)]
 	}
 	return state
 }
 
 func restorePlacementState[TCell any](grid *Grid[TCell], state placementState[TCell]) {
 	for i, pos := range state.positions {
-		grid.rows[pos[1]][pos[0]] = state.values[i]
+		grid.cells[gridIndex(grid, pos[0], pos[1])] = state.values[i]
 	}
 }
 
@@ -476,21 +736,21 @@ func gridCanFitShapesDFS[TCell any](
 		shapeHeight := maxDY - minDY + 1
 
 		// Skip if shape is too large for grid
-		if shapeWidth > grid.sizeX || shapeHeight > grid.sizeY {
+		if shapeWidth > grid.width || shapeHeight > grid.height {
 			continue
 		}
 
 		// Only check anchor positions where the shape could fit
-		maxAnchorY := grid.sizeY - shapeHeight
-		maxAnchorX := grid.sizeX - shapeWidth
+		maxAnchorY := grid.height - shapeHeight
+		maxAnchorX := grid.width - shapeWidth
 
-		for anchorY := 0; anchorY <= maxAnchorY && anchorY < grid.sizeY; anchorY++ {
-			for anchorX := 0; anchorX <= maxAnchorX && anchorX < grid.sizeX; anchorX++ {
+		for anchorY := 0; anchorY <= maxAnchorY && anchorY < grid.height; anchorY++ {
+			for anchorX := 0; anchorX <= maxAnchorX && anchorX < grid.width; anchorX++ {
 				// Quick pre-check: if first cell is occupied, skip
 				firstOffset := transformedShape[0]
 				firstX := anchorX + firstOffset.DX
 				firstY := anchorY + firstOffset.DY
-				if !isSuitable(grid.rows[firstY][firstX], firstX, firstY) {
+				if !isSuitable(grid.cells[gridIndex(grid, firstX, firstY)], firstX, firstY) {
 					continue
 				}
 
@@ -529,42 +789,41 @@ func GridCanFitShapes[TCell any](
 	isSuitable func(cell TCell, x, y int) bool,
 	markerValue TCell,
 ) bool {
-	if len(shapes) == 0 {
+	shapeTransformations, remainingShapes := planShapePlacement(shapes, shapeCounts, flags)
+	if len(remainingShapes) == 0 {
 		return true
 	}
 
+	shapeCountsCopy := make(map[int]int)
+	for k, v := range shapeCounts {
+		shapeCountsCopy[k] = v
+	}
+
+	return gridCanFitShapesDFS(grid, shapeCountsCopy, shapeTransformations, remainingShapes, isSuitable, markerValue)
+}
+
+// planShapePlacement lists the orientations of every shape still needed and the order to place them in.
+func planShapePlacement(shapes map[int][]ShapeOffset, shapeCounts map[int]int, flags PlacementFlags) (map[int][][]ShapeOffset, []int) {
 	shapeTransformations := make(map[int][][]ShapeOffset)
 	remainingShapes := make([]int, 0)
 	shapeConstraints := make(map[int]int) // Number of transformations (fewer = more constrained)
 
-	for shapeID, shape := range shapes {
+	// Shape IDs are visited in order so that ties in the sort below, and so the search, are reproducible.
+	for _, shapeID := range slices.Sorted(maps.Keys(shapes)) {
 		if shapeCounts[shapeID] <= 0 {
 			continue
 		}
-		transformations := generateShapeTransformations(shape, flags)
+		transformations := generateShapeTransformations(shapes[shapeID], flags)
 		shapeTransformations[shapeID] = transformations
 		shapeConstraints[shapeID] = len(transformations)
 		remainingShapes = append(remainingShapes, shapeID)
 	}
 
-	if len(remainingShapes) == 0 {
-		return true
-	}
-
 	// Sort shapes by constraint level (fewer transformations = more constrained = try first)
 	// This helps prune the search tree faster
-	for i := 0; i < len(remainingShapes)-1; i++ {
-		for j := i + 1; j < len(remainingShapes); j++ {
-			if shapeConstraints[remainingShapes[i]] > shapeConstraints[remainingShapes[j]] {
-				remainingShapes[i], remainingShapes[j] = remainingShapes[j], remainingShapes[i]
-			}
-		}
-	}
-
-	shapeCountsCopy := make(map[int]int)
-	for k, v := range shapeCounts {
-		shapeCountsCopy[k] = v
-	}
+	slices.SortStableFunc(remainingShapes, func(a, b int) int {
+		return shapeConstraints[a] - shapeConstraints[b]
+	})
 
-	return gridCanFitShapesDFS(grid, shapeCountsCopy, shapeTransformations, remainingShapes, isSuitable, markerValue)
+	return shapeTransformations, remainingShapes
 }
@@ -0,0 +1,153 @@
+package aocshared
+
+import (
+	"testing"
+)
+
+// benchRollGrid builds a day 4 sized paper roll floor with a fixed pseudo-random fill.
+func benchRollGrid(size int) [][]bool {
+	state := uint32(2025)
+	rows := make([][]bool, size)
+	for y := range rows {
+		rows[y] = make([]bool, size)
+		for x := range rows[y] {
+			state = state*1664525 + 1013904223
+			rows[y][x] = state>>28 < 10
+		}
+	}
+	return rows
+}
+
+// Day 4: repeatedly remove every roll with fewer than four neighbours until none can be lifted.
+func BenchmarkGridDay4Removal(b *testing.B) {
+	rows := benchRollGrid(140)
+
+	for b.Loop() {
+		grid := GridCreate(cloneRows(rows))
+
+		for {
+			lifted := 0
+			grid.ForEach(func(cell bool, x, y int) {
+				if !cell {
+					return
+				}
+
+				neighbours := 0
+				for _, adjacent := range grid.Adjacencies(x, y) {
+					if adjacent {
+						neighbours++
+					}
+				}
+
+				if neighbours < 4 {
+					lifted++
+					GridQueueOp(grid, func() {
+						GridSetAt(grid, x, y, false)
+					})
+				}
+			})
+
+			if lifted == 0 {
+				break
+			}
+			GridQueuedOpsApply(grid)
+		}
+	}
+}
+
+// Day 4 again, on the double-buffered automaton with and without active-cell tracking.
+func BenchmarkAutomatonDay4Removal(b *testing.B) {
+	rows := benchRollGrid(140)
+	rule := func(cell bool, neighbors [8]bool, x, y int) bool {
+		count := 0
+		for _, adjacent := range neighbors {
+			if adjacent {
+				count++
+			}
+		}
+		return cell && count >= 4
+	}
+
+	b.Run("full", func(b *testing.B) {
+		for b.Loop() {
+			AutomatonRunUntilStable(AutomatonCreate(GridCreate(cloneRows(rows)), rule))
+		}
+	})
+
+	b.Run("active", func(b *testing.B) {
+		for b.Loop() {
+			automaton := AutomatonCreate(GridCreate(cloneRows(rows)), rule)
+			AutomatonTrackActive(automaton)
+			AutomatonRunUntilStable(automaton)
+		}
+	})
+}
+
+func BenchmarkGridGetAt(b *testing.B) {
+	grid := GridCreate(benchRollGrid(140))
+
+	for b.Loop() {
+		count := 0
+		for y := 0; y < 140; y++ {
+			for x := 0; x < 140; x++ {
+				if cell, _ := grid.At(x, y); cell {
+					count++
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkGridClone(b *testing.B) {
+	grid := GridCreate(benchRollGrid(140))
+
+	for b.Loop() {
+		GridClone(grid)
+	}
+}
+
+// Day 12: pack present shapes into a region by backtracking.
+func BenchmarkGridDay12Packing(b *testing.B) {
+	shapes := map[int][]ShapeOffset{
+		0: {{0, 0}, {1, 0}, {2, 0}, {0, 1}, {0, 2}, {1, 2}, {2, 2}},
+		1: {{0, 0}, {1, 0}, {1, 1}, {2, 1}, {2, 2}},
+		2: {{0, 0}, {1, 0}, {2, 0}, {1, 1}, {1, 2}},
+		3: {{0, 0}, {1, 0}, {0, 1}, {1, 1}, {2, 1}, {2, 2}},
+	}
+	counts := map[int]int{0: 2, 1: 2, 2: 2, 3: 2}
+	flags := PlacementFlags{AllowRotate: true, AllowFlip: true}
+	isFree := func(cell bool, x, y int) bool { return !cell }
+
+	for b.Loop() {
+		rows := make([][]bool, 6)
+		for y := range rows {
+			rows[y] = make([]bool, 12)
+		}
+
+		GridCanFitShapes(GridCreate(rows), shapes, counts, flags, isFree, true)
+	}
+}
+
+func cloneRows(rows [][]bool) [][]bool {
+	cloned := make([][]bool, len(rows))
+	for y := range rows {
+		cloned[y] = append([]bool(nil), rows[y]...)
+	}
+	return cloned
+}
+
+// Day 12 again, with the region held as bit masks.
+func BenchmarkBitGridDay12Packing(b *testing.B) {
+	shapes := map[int][]ShapeOffset{
+		0: {{0, 0}, {1, 0}, {2, 0}, {0, 1}, {0, 2}, {1, 2}, {2, 2}},
+		1: {{0, 0}, {1, 0}, {1, 1}, {2, 1}, {2, 2}},
+		2: {{0, 0}, {1, 0}, {2, 0}, {1, 1}, {1, 2}},
+		3: {{0, 0}, {1, 0}, {0, 1}, {1, 1}, {2, 1}, {2, 2}},
+	}
+	counts := map[int]int{0: 2, 1: 2, 2: 2, 3: 2}
+	flags := PlacementFlags{AllowRotate: true, AllowFlip: true}
+
+	for b.Loop() {
+		BitGridCanFitShapes(BitGridCreate(12, 6), shapes, counts, flags)
+	}
+}
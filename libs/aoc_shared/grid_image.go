@@ -0,0 +1,122 @@
+package aocshared
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// GridImage draws every cell as a cellSize x cellSize square of the colour cellColor picks for it.
+// Sparse grids are drawn over their bounding box.
+func GridImage[TCell any](grid CellGrid[TCell], cellSize int, cellColor func(cell TCell) color.Color) *image.RGBA {
+	minPoint, maxPoint := grid.Bounds()
+	width, height := max(maxPoint.X-minPoint.X+1, 0), max(maxPoint.Y-minPoint.Y+1, 0)
+
+	img := image.NewRGBA(image.Rect(0, 0, width*cellSize, height*cellSize))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			cell, _ := grid.At(minPoint.X+x, minPoint.Y+y)
+			square := image.Rect(x*cellSize, y*cellSize, (x+1)*cellSize, (y+1)*cellSize)
+			draw.Draw(img, square, image.NewUniform(cellColor(cell)), image.Point{}, draw.Src)
+		}
+	}
+
+	return img
+}
+
+func GridEncodePNG[TCell any](w io.Writer, grid CellGrid[TCell], cellSize int, cellColor func(cell TCell) color.Color) error {
+	return png.Encode(w, GridImage(grid, cellSize, cellColor))
+}
+
+// GridSavePNG writes the grid as a PNG file, creating parent directories as needed.
+func GridSavePNG[TCell any](path string, grid CellGrid[TCell], cellSize int, cellColor func(cell TCell) color.Color) error {
+	return writeImageFile(path, func(w io.Writer) error {
+		return GridEncodePNG(w, grid, cellSize, cellColor)
+	})
+}
+
+// GridRecorder collects grid snapshots as frames of an animated GIF.
+type GridRecorder[TCell any] struct {
+	cellSize  int
+	delay     int // per frame, in hundredths of a second
+	palette   color.Palette
+	cellColor func(cell TCell) color.Color
+
+	animation gif.GIF
+}
+
+// GridRecorderCreate prepares a recording. Colours are snapped to the nearest palette entry;
+// a nil palette uses the 256-colour Plan 9 palette.
+func GridRecorderCreate[TCell any](cellSize, delay int, colors color.Palette, cellColor func(cell TCell) color.Color) *GridRecorder[TCell] {
+	if colors == nil {
+		colors = palette.Plan9
+	}
+
+	return &GridRecorder[TCell]{
+		cellSize:  cellSize,
+		delay:     delay,
+		palette:   colors,
+		cellColor: cellColor,
+	}
+}
+
+// GridRecorderAddFrame captures the grid as it is now. Frames may differ in size; the GIF uses the largest.
+func GridRecorderAddFrame[TCell any](recorder *GridRecorder[TCell], grid CellGrid[TCell]) {
+	frame := GridImage(grid, recorder.cellSize, recorder.cellColor)
+
+	paletted := image.NewPaletted(frame.Bounds(), recorder.palette)
+	draw.Draw(paletted, frame.Bounds(), frame, image.Point{}, draw.Src)
+
+	recorder.animation.Image = append(recorder.animation.Image, paletted)
+	recorder.animation.Delay = append(recorder.animation.Delay, recorder.delay)
+}
+
+func GridRecorderFrames[TCell any](recorder *GridRecorder[TCell]) int {
+	return len(recorder.animation.Image)
+}
+
+func GridRecorderEncodeGIF[TCell any](w io.Writer, recorder *GridRecorder[TCell]) error {
+	if len(recorder.animation.Image) == 0 {
+		return fmt.Errorf("no frames recorded")
+	}
+
+	bounds := image.Rectangle{}
+	for _, frame := range recorder.animation.Image {
+		bounds = bounds.Union(frame.Bounds())
+	}
+	recorder.animation.Config = image.Config{ColorModel: recorder.palette, Width: bounds.Dx(), Height: bounds.Dy()}
+
+	return gif.EncodeAll(w, &recorder.animation)
+}
+
+// GridRecorderSaveGIF writes the recording as a GIF file, creating parent directories as needed.
+func GridRecorderSaveGIF[TCell any](path string, recorder *GridRecorder[TCell]) error {
+	return writeImageFile(path, func(w io.Writer) error {
+		return GridRecorderEncodeGIF(w, recorder)
+	})
+}
+
+func writeImageFile(path string, encode func(w io.Writer) error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := encode(file); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,74 @@
+package aocshared
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+type Point struct {
+	X int
+	Y int
+}
+
+// GridParse builds a grid from text, one row per line and one cell per rune, looking each rune up in cells.
+// Trailing blank lines are ignored; rows shorter than the widest one are padded with the zero cell.
+// The positions of every rune in markers are returned in reading order.
+// A character missing from cells is reported as a ParseError at its row and column.
+func GridParse[TCell any](input string, cells map[rune]TCell, markers ...rune) (*Grid[TCell], map[rune][]Point, error) {
+	return GridParseFunc(input, func(char rune) (TCell, error) {
+		cell, ok := cells[char]
+		if !ok {
+			return cell, fmt.Errorf("not in the rune map")
+		}
+		return cell, nil
+	}, markers...)
+}
+
+// GridParseFunc is GridParse with a conversion function; its errors are reported at the offending rune.
+func GridParseFunc[TCell any](input string, convert func(rune) (TCell, error), markers ...rune) (*Grid[TCell], map[rune][]Point, error) {
+	lines := strings.Split(strings.ReplaceAll(input, "\r\n", "\n"), "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) == 0 {
+		return nil, nil, fmt.Errorf("grid input is empty")
+	}
+
+	width := 0
+	for _, line := range lines {
+		width = max(width, utf8.RuneCountInString(line))
+	}
+
+	found := make(map[rune][]Point, len(markers))
+	for _, marker := range markers {
+		found[marker] = make([]Point, 0)
+	}
+
+	grid := &Grid[TCell]{
+		cells:     make([]TCell, width*len(lines)),
+		width:     width,
+		height:    len(lines),
+		queuedOps: make([]func(), 0),
+	}
+
+	for y, line := range lines {
+		x := 0
+		for _, char := range line {
+			cell, err := convert(char)
+			if err != nil {
+				return nil, nil, &ParseError{Line: y + 1, Column: x + 1, Msg: fmt.Sprintf("unknown grid character %q", char), Err: err}
+			}
+
+			if positions, ok := found[char]; ok {
+				found[char] = append(positions, Point{X: x, Y: y})
+			}
+
+			grid.cells[gridIndex(grid, x, y)] = cell
+			x++
+		}
+	}
+
+	return grid, found, nil
+}
@@ -0,0 +1,192 @@
+package aocshared
+
+import "fmt"
+
+// The transforms below return new grids and leave the input untouched. Queued ops are not carried
+// over since they close over the original grid. Wrap modes follow their axes when those are swapped,
+// and an axis only stays wrapped through a cut or join when its opposite edges still meet.
+
+// GridRotate90 rotates the grid a quarter turn clockwise, the same way rotateShape90 turns a shape.
+func GridRotate90[TCell any](grid *Grid[TCell]) *Grid[TCell] {
+	return gridRemap(grid, grid.height, grid.width, swapWrapAxes(grid.wrap), func(x, y int) (int, int) {
+		return y, grid.height - 1 - x
+	})
+}
+
+func GridRotate180[TCell any](grid *Grid[TCell]) *Grid[TCell] {
+	return gridRemap(grid, grid.width, grid.height, grid.wrap, func(x, y int) (int, int) {
+		return grid.width - 1 - x, grid.height - 1 - y
+	})
+}
+
+// GridRotate270 rotates the grid a quarter turn counter-clockwise.
+func GridRotate270[TCell any](grid *Grid[TCell]) *Grid[TCell] {
+	return gridRemap(grid, grid.height, grid.width, swapWrapAxes(grid.wrap), func(x, y int) (int, int) {
+		return grid.width - 1 - y, x
+	})
+}
+
+// GridTranspose mirrors the grid along its main diagonal, turning rows into columns.
+func GridTranspose[TCell any](grid *Grid[TCell]) *Grid[TCell] {
+	return gridRemap(grid, grid.height, grid.width, swapWrapAxes(grid.wrap), func(x, y int) (int, int) {
+		return y, x
+	})
+}
+
+// GridFlipHorizontal mirrors the grid left to right.
+func GridFlipHorizontal[TCell any](grid *Grid[TCell]) *Grid[TCell] {
+	return gridRemap(grid, grid.width, grid.height, grid.wrap, func(x, y int) (int, int) {
+		return grid.width - 1 - x, y
+	})
+}
+
+// GridFlipVertical mirrors the grid top to bottom.
+func GridFlipVertical[TCell any](grid *Grid[TCell]) *Grid[TCell] {
+	return gridRemap(grid, grid.width, grid.height, grid.wrap, func(x, y int) (int, int) {
+		return x, grid.height - 1 - y
+	})
+}
+
+// gridRemap builds a width x height grid whose cell (x, y) is the source cell that source picks for it.
+func gridRemap[TCell any](grid *Grid[TCell], width, height int, wrap WrapMode, source func(x, y int) (int, int)) *Grid[TCell] {
+	remapped := &Grid[TCell]{
+		cells:     make([]TCell, width*height),
+		width:     width,
+		height:    height,
+		queuedOps: make([]func(), 0),
+		wrap:      wrap,
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			srcX, srcY := source(x, y)
+			remapped.cells[gridIndex(remapped, x, y)] = grid.cells[gridIndex(grid, srcX, srcY)]
+		}
+	}
+
+	return remapped
+}
+
+func swapWrapAxes(mode WrapMode) WrapMode {
+	swapped := WrapNone
+	if mode&WrapX != 0 {
+		swapped |= WrapY
+	}
+	if mode&WrapY != 0 {
+		swapped |= WrapX
+	}
+	return swapped
+}
+
+// GridSubGrid copies the width x height rectangle starting at origin into a new grid.
+// It wraps along an axis only when the grid does and the rectangle spans that whole axis.
+func GridSubGrid[TCell any](grid *Grid[TCell], origin Point, width, height int) (*Grid[TCell], error) {
+	view, err := GridViewCreate(grid, origin, width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &Grid[TCell]{
+		cells:     make([]TCell, 0, width*height),
+		width:     width,
+		height:    height,
+		queuedOps: make([]func(), 0),
+	}
+	if width == grid.width {
+		sub.wrap |= grid.wrap & WrapX
+	}
+	if height == grid.height {
+		sub.wrap |= grid.wrap & WrapY
+	}
+	for _, row := range view.Rows() {
+		sub.cells = append(sub.cells, row...)
+	}
+
+	return sub, nil
+}
+
+// GridTile repeats the grid timesX times across and timesY times down.
+func GridTile[TCell any](grid *Grid[TCell], timesX, timesY int) *Grid[TCell] {
+	tiled := &Grid[TCell]{
+		cells:     make([]TCell, 0, len(grid.cells)*timesX*timesY),
+		width:     grid.width * timesX,
+		height:    grid.height * timesY,
+		queuedOps: make([]func(), 0),
+		wrap:      grid.wrap,
+	}
+
+	for tileY := 0; tileY < timesY; tileY++ {
+		for _, row := range grid.Rows() {
+			for tileX := 0; tileX < timesX; tileX++ {
+				tiled.cells = append(tiled.cells, row...)
+			}
+		}
+	}
+
+	return tiled
+}
+
+// GridConcatHorizontal places grids of equal height side by side, left to right.
+// The result wraps vertically when every input does, and never horizontally.
+func GridConcatHorizontal[TCell any](grids ...*Grid[TCell]) (*Grid[TCell], error) {
+	if len(grids) == 0 {
+		return nil, fmt.Errorf("no grids to concatenate")
+	}
+
+	width, height := 0, grids[0].height
+	wrap := WrapY
+	for i, grid := range grids {
+		if grid.height != height {
+			return nil, fmt.Errorf("grid %d has height %d, expected %d", i, grid.height, height)
+		}
+		width += grid.width
+		wrap &= grid.wrap
+	}
+
+	joined := &Grid[TCell]{
+		cells:     make([]TCell, 0, width*height),
+		width:     width,
+		height:    height,
+		queuedOps: make([]func(), 0),
+		wrap:      wrap,
+	}
+	for y := 0; y < height; y++ {
+		for _, grid := range grids {
+			row, _ := grid.Row(y)
+			joined.cells = append(joined.cells, row...)
+		}
+	}
+
+	return joined, nil
+}
+
+// GridConcatVertical stacks grids of equal width, top to bottom.
+// The result wraps horizontally when every input does, and never vertically.
+func GridConcatVertical[TCell any](grids ...*Grid[TCell]) (*Grid[TCell], error) {
+	if len(grids) == 0 {
+		return nil, fmt.Errorf("no grids to concatenate")
+	}
+
+	width, height := grids[0].width, 0
+	wrap := WrapX
+	for i, grid := range grids {
+		if grid.width != width {
+			return nil, fmt.Errorf("grid %d has width %d, expected %d", i, grid.width, width)
+		}
+		height += grid.height
+		wrap &= grid.wrap
+	}
+
+	joined := &Grid[TCell]{
+		cells:     make([]TCell, 0, width*height),
+		width:     width,
+		height:    height,
+		queuedOps: make([]func(), 0),
+		wrap:      wrap,
+	}
+	for _, grid := range grids {
+		joined.cells = append(joined.cells, grid.cells...)
+	}
+
+	return joined, nil
+}
@@ -0,0 +1,158 @@
+package aocshared
+
+import (
+	"fmt"
+	"iter"
+)
+
+// GridView is a rectangle of a Grid that shares its storage: writes through the view change the parent.
+// Coordinates are relative to the view's top-left corner and never wrap.
+type GridView[TCell any] struct {
+	parent *Grid[TCell]
+	origin Point
+
+	width  int
+	height int
+}
+
+var _ CellGrid[int] = (*GridView[int])(nil)
+
+// GridViewCreate views the width x height rectangle starting at origin, which must lie inside the grid.
+func GridViewCreate[TCell any](grid *Grid[TCell], origin Point, width, height int) (*GridView[TCell], error) {
+	if width < 0 || height < 0 || origin.X < 0 || origin.Y < 0 ||
+		origin.X+width > grid.width || origin.Y+height > grid.height {
+		return nil, fmt.Errorf("view %dx%d at (%d, %d) does not fit in a %dx%d grid",
+			width, height, origin.X, origin.Y, grid.width, grid.height)
+	}
+
+	return &GridView[TCell]{
+		parent: grid,
+		origin: origin,
+		width:  width,
+		height: height,
+	}, nil
+}
+
+func (view *GridView[TCell]) Width() int {
+	return view.width
+}
+
+func (view *GridView[TCell]) Height() int {
+	return view.height
+}
+
+// Origin is where the view's top-left corner sits in the parent grid.
+func (view *GridView[TCell]) Origin() Point {
+	return view.origin
+}
+
+func (view *GridView[TCell]) parentIndex(x, y int) int {
+	return gridIndex(view.parent, view.origin.X+x, view.origin.Y+y)
+}
+
+func (view *GridView[TCell]) InBounds(x, y int) bool {
+	return x >= 0 && y >= 0 && x < view.width && y < view.height
+}
+
+func (view *GridView[TCell]) At(x, y int) (TCell, error) {
+	if !view.InBounds(x, y) {
+		var zero TCell
+		return zero, fmt.Errorf("invalid coords")
+	}
+
+	return view.parent.cells[view.parentIndex(x, y)], nil
+}
+
+func (view *GridView[TCell]) Set(x, y int, cell TCell) error {
+	if !view.InBounds(x, y) {
+		return fmt.Errorf("invalid coords")
+	}
+
+	view.parent.cells[view.parentIndex(x, y)] = cell
+
+	return nil
+}
+
+func (view *GridView[TCell]) Bounds() (minPoint, maxPoint Point) {
+	return Point{}, Point{X: view.width - 1, Y: view.height - 1}
+}
+
+// Row returns row y of the view backed by the parent's storage.
+func (view *GridView[TCell]) Row(y int) ([]TCell, error) {
+	if y < 0 || y >= view.height {
+		return nil, fmt.Errorf("invalid coords")
+	}
+
+	start := view.parentIndex(0, y)
+	return view.parent.cells[start : start+view.width : start+view.width], nil
+}
+
+// Col copies column x of the view top to bottom.
+func (view *GridView[TCell]) Col(x int) ([]TCell, error) {
+	if x < 0 || x >= view.width {
+		return nil, fmt.Errorf("invalid coords")
+	}
+
+	col := make([]TCell, view.height)
+	for y := range col {
+		col[y] = view.parent.cells[view.parentIndex(x, y)]
+	}
+	return col, nil
+}
+
+// Rows yields every row of the view, top to bottom, backed by the parent's storage.
+func (view *GridView[TCell]) Rows() iter.Seq2[int, []TCell] {
+	return func(yield func(int, []TCell) bool) {
+		for y := 0; y < view.height; y++ {
+			row, _ := view.Row(y)
+			if !yield(y, row) {
+				return
+			}
+		}
+	}
+}
+
+// All yields every cell of the view in reading order.
+func (view *GridView[TCell]) All() iter.Seq2[Point, TCell] {
+	return func(yield func(Point, TCell) bool) {
+		for y, row := range view.Rows() {
+			for x, cell := range row {
+				if !yield(Point{X: x, Y: y}, cell) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Neighbors yields the neighbours of p inside the view, clockwise from north.
+func (view *GridView[TCell]) Neighbors(p Point, conn Connectivity) iter.Seq[Point] {
+	directions := orthogonalDirections
+	if conn == Conn8 {
+		directions = allDirections
+	}
+
+	return func(yield func(Point) bool) {
+		for _, d := range directions {
+			x, y := d.ApplyDelta(p.X, p.Y)
+			if view.InBounds(x, y) && !yield(Point{X: x, Y: y}) {
+				return
+			}
+		}
+	}
+}
+
+// Adjacencies returns the eight surrounding cells indexed by Direction; cells outside the view are the zero cell.
+func (view *GridView[TCell]) Adjacencies(x, y int) [8]TCell {
+	return cellAdjacencies(view, x, y)
+}
+
+func (view *GridView[TCell]) ForEach(exec func(cell TCell, x, y int)) {
+	for p, cell := range view.All() {
+		exec(cell, p.X, p.Y)
+	}
+}
+
+func (view *GridView[TCell]) Debug(cellFormatter func(cell TCell) string) {
+	debugCells(view, cellFormatter)
+}
@@ -0,0 +1,276 @@
+package aocshared
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"strings"
+)
+
+// Hex is a hexagon in axial coordinates. The third cube coordinate is implied: S = -Q - R.
+// The same coordinates serve both layouts; only the drawing and the direction names differ.
+type Hex struct {
+	Q, R int
+}
+
+// HexCube is a hexagon in cube coordinates, where Q + R + S is always 0.
+type HexCube struct {
+	Q, R, S int
+}
+
+// HexLayout picks how hexagons sit on screen.
+type HexLayout int
+
+const (
+	HexPointyTop HexLayout = iota // rows of hexagons, neighbours to the east and west
+	HexFlatTop                    // columns of hexagons, neighbours to the north and south
+)
+
+// HexDirection is one of the six neighbour directions, counter-clockwise on screen.
+type HexDirection int
+
+// Direction names in the pointy-top layout.
+const (
+	HexE HexDirection = iota
+	HexNE
+	HexNW
+	HexW
+	HexSW
+	HexSE
+	HexDirectionCount
+)
+
+// The same six directions named for the flat-top layout.
+const (
+	HexFlatSE HexDirection = iota
+	HexFlatNE
+	HexFlatN
+	HexFlatNW
+	HexFlatSW
+	HexFlatS
+)
+
+var HexDelta = [HexDirectionCount]Hex{
+	{Q: 1, R: 0}, {Q: 1, R: -1}, {Q: 0, R: -1},
+	{Q: -1, R: 0}, {Q: -1, R: 1}, {Q: 0, R: 1},
+}
+
+var hexDirectionNames = map[HexLayout][HexDirectionCount]string{
+	HexPointyTop: {"e", "ne", "nw", "w", "sw", "se"},
+	HexFlatTop:   {"se", "ne", "n", "nw", "sw", "s"},
+}
+
+func (d HexDirection) Opposite() HexDirection {
+	return (d + 3) % 6
+}
+
+func (d HexDirection) RotateCW() HexDirection {
+	return (d + 5) % 6
+}
+
+func (d HexDirection) RotateCCW() HexDirection {
+	return (d + 1) % 6
+}
+
+// Name is the lower case compass name of the direction in the layout, as puzzle inputs spell it.
+func (d HexDirection) Name(layout HexLayout) string {
+	return hexDirectionNames[layout][d]
+}
+
+// ParseHexDirection reads a compass name such as "ne" or "S" in the given layout.
+func ParseHexDirection(s string, layout HexLayout) (HexDirection, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	for d, candidate := range hexDirectionNames[layout] {
+		if candidate == name {
+			return HexDirection(d), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid hex direction %q", s)
+}
+
+// ParseHexPath reads a list of directions, either comma separated ("ne,ne,s") or run together ("esenee").
+// Run-together names are read two letters first, so "nee" is ne then e.
+func ParseHexPath(s string, layout HexLayout) ([]HexDirection, error) {
+	path := make([]HexDirection, 0)
+
+	if strings.Contains(s, ",") {
+		for _, name := range strings.Split(s, ",") {
+			d, err := ParseHexDirection(name, layout)
+			if err != nil {
+				return nil, err
+			}
+			path = append(path, d)
+		}
+		return path, nil
+	}
+
+	rest := strings.TrimSpace(s)
+	for len(rest) > 0 {
+		if len(rest) >= 2 {
+			if d, err := ParseHexDirection(rest[:2], layout); err == nil {
+				path = append(path, d)
+				rest = rest[2:]
+				continue
+			}
+		}
+
+		d, err := ParseHexDirection(rest[:1], layout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex direction at %q", rest)
+		}
+		path = append(path, d)
+		rest = rest[1:]
+	}
+
+	return path, nil
+}
+
+func (h Hex) Cube() HexCube {
+	return HexCube{Q: h.Q, R: h.R, S: -h.Q - h.R}
+}
+
+func (c HexCube) Axial() Hex {
+	return Hex{Q: c.Q, R: c.R}
+}
+
+func (h Hex) Add(other Hex) Hex {
+	return Hex{Q: h.Q + other.Q, R: h.R + other.R}
+}
+
+func (h Hex) Sub(other Hex) Hex {
+	return Hex{Q: h.Q - other.Q, R: h.R - other.R}
+}
+
+func (h Hex) Scale(factor int) Hex {
+	return Hex{Q: h.Q * factor, R: h.R * factor}
+}
+
+func (h Hex) Neighbor(d HexDirection) Hex {
+	return h.Add(HexDelta[d])
+}
+
+// Neighbors yields the six surrounding hexagons in direction order.
+func (h Hex) Neighbors() iter.Seq[Hex] {
+	return func(yield func(Hex) bool) {
+		for _, delta := range HexDelta {
+			if !yield(h.Add(delta)) {
+				return
+			}
+		}
+	}
+}
+
+// Walk follows the path one step per direction.
+func (h Hex) Walk(path []HexDirection) Hex {
+	for _, d := range path {
+		h = h.Neighbor(d)
+	}
+	return h
+}
+
+// Distance is the number of steps between two hexagons.
+func (h Hex) Distance(other Hex) int {
+	d := h.Sub(other).Cube()
+	return max(abs(d.Q), abs(d.R), abs(d.S))
+}
+
+// Ring returns the hexagons exactly radius steps away, going counter-clockwise from the south-west corner.
+// A negative radius gives no hexagons.
+func (h Hex) Ring(radius int) []Hex {
+	if radius < 0 {
+		return nil
+	}
+	if radius == 0 {
+		return []Hex{h}
+	}
+
+	ring := make([]Hex, 0, 6*radius)
+	current := h.Add(HexDelta[HexSW].Scale(radius))
+	for d := HexE; d < HexDirectionCount; d++ {
+		for range radius {
+			ring = append(ring, current)
+			current = current.Neighbor(d)
+		}
+	}
+	return ring
+}
+
+// Spiral returns h followed by every ring out to radius, or nothing for a negative radius.
+func (h Hex) Spiral(radius int) []Hex {
+	if radius < 0 {
+		return nil
+	}
+	spiral := []Hex{h}
+	for r := 1; r <= radius; r++ {
+		spiral = append(spiral, h.Ring(r)...)
+	}
+	return spiral
+}
+
+// LineTo returns the hexagons on the straight line from h to other, both ends included.
+func (h Hex) LineTo(other Hex) []Hex {
+	steps := h.Distance(other)
+	line := make([]Hex, 0, steps+1)
+
+	from, to := h.Cube(), other.Cube()
+	for i := 0; i <= steps; i++ {
+		t := 0.0
+		if steps > 0 {
+			t = float64(i) / float64(steps)
+		}
+
+		// The nudge keeps lines that run exactly along hexagon edges from rounding both ways.
+		q := lerp(float64(from.Q)+1e-6, float64(to.Q)+1e-6, t)
+		r := lerp(float64(from.R)+2e-6, float64(to.R)+2e-6, t)
+		line = append(line, hexRound(q, r))
+	}
+	return line
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+// hexRound snaps fractional axial coordinates to the hexagon containing them.
+func hexRound(fq, fr float64) Hex {
+	fs := -fq - fr
+	q, r, s := math.Round(fq), math.Round(fr), math.Round(fs)
+
+	dq, dr, ds := math.Abs(q-fq), math.Abs(r-fr), math.Abs(s-fs)
+	if dq > dr && dq > ds {
+		q = -r - s
+	} else if dr > ds {
+		r = -q - s
+	}
+
+	return Hex{Q: int(q), R: int(r)}
+}
+
+// RotateCW turns h a sixth of a turn clockwise on screen around center.
+func (h Hex) RotateCW(center Hex) Hex {
+	c := h.Sub(center).Cube()
+	return HexCube{Q: -c.R, R: -c.S, S: -c.Q}.Axial().Add(center)
+}
+
+// RotateCCW turns h a sixth of a turn counter-clockwise on screen around center.
+func (h Hex) RotateCCW(center Hex) Hex {
+	c := h.Sub(center).Cube()
+	return HexCube{Q: -c.S, R: -c.Q, S: -c.R}.Axial().Add(center)
+}
+
+// HexToPixel gives the centre of h on screen, y pointing down, for hexagons of the given corner radius.
+func HexToPixel(h Hex, layout HexLayout, size float64) (x, y float64) {
+	q, r := float64(h.Q), float64(h.R)
+	if layout == HexFlatTop {
+		return size * 1.5 * q, size * (math.Sqrt(3)/2*q + math.Sqrt(3)*r)
+	}
+	return size * (math.Sqrt(3)*q + math.Sqrt(3)/2*r), size * 1.5 * r
+}
+
+// PixelToHex finds the hexagon containing a point on screen, the inverse of HexToPixel.
+func PixelToHex(x, y float64, layout HexLayout, size float64) Hex {
+	if layout == HexFlatTop {
+		return hexRound(2.0/3*x/size, (-1.0/3*x+math.Sqrt(3)/3*y)/size)
+	}
+	return hexRound((math.Sqrt(3)/3*x-1.0/3*y)/size, 2.0/3*y/size)
+}
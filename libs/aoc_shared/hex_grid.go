@@ -0,0 +1,174 @@
+package aocshared
+
+import (
+	"cmp"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"iter"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+)
+
+// HexGrid stores cells on hexagons, like SparseGrid does for squares: unset hexagons read as the fill value.
+type HexGrid[TCell any] struct {
+	cells map[Hex]TCell
+	fill  TCell
+}
+
+func HexGridCreate[TCell any](fill TCell) *HexGrid[TCell] {
+	return &HexGrid[TCell]{
+		cells: make(map[Hex]TCell),
+		fill:  fill,
+	}
+}
+
+func HexGridClone[TCell any](grid *HexGrid[TCell]) *HexGrid[TCell] {
+	return &HexGrid[TCell]{
+		cells: maps.Clone(grid.cells),
+		fill:  grid.fill,
+	}
+}
+
+func (grid *HexGrid[TCell]) Len() int {
+	return len(grid.cells)
+}
+
+func (grid *HexGrid[TCell]) At(h Hex) TCell {
+	if cell, ok := grid.cells[h]; ok {
+		return cell
+	}
+	return grid.fill
+}
+
+func (grid *HexGrid[TCell]) Has(h Hex) bool {
+	_, ok := grid.cells[h]
+	return ok
+}
+
+func (grid *HexGrid[TCell]) Set(h Hex, cell TCell) {
+	grid.cells[h] = cell
+}
+
+func (grid *HexGrid[TCell]) Delete(h Hex) {
+	delete(grid.cells, h)
+}
+
+// All yields the set hexagons row by row (by R, then Q).
+func (grid *HexGrid[TCell]) All() iter.Seq2[Hex, TCell] {
+	return func(yield func(Hex, TCell) bool) {
+		keys := slices.SortedFunc(maps.Keys(grid.cells), func(a, b Hex) int {
+			return cmp.Or(cmp.Compare(a.R, b.R), cmp.Compare(a.Q, b.Q))
+		})
+		for _, h := range keys {
+			if !yield(h, grid.cells[h]) {
+				return
+			}
+		}
+	}
+}
+
+// HexGridCount counts the hexagons, set ones only, whose cell matches.
+func HexGridCount[TCell any](grid *HexGrid[TCell], match func(cell TCell) bool) int {
+	count := 0
+	for _, cell := range grid.cells {
+		if match(cell) {
+			count++
+		}
+	}
+	return count
+}
+
+// hexTextPosition places a hexagon on a character grid: pointy-top rows are offset by half a hexagon
+// per row, flat-top columns by half a hexagon per column. Every hexagon is one character wide.
+func hexTextPosition(h Hex, layout HexLayout) Point {
+	if layout == HexFlatTop {
+		return Point{X: 2 * h.Q, Y: 2*h.R + h.Q}
+	}
+	return Point{X: 2*h.Q + h.R, Y: h.R}
+}
+
+// HexGridString draws the set hexagons as text, one glyph each, with spaces between and around them.
+func HexGridString[TCell any](grid *HexGrid[TCell], layout HexLayout, glyph func(cell TCell) rune) string {
+	if len(grid.cells) == 0 {
+		return ""
+	}
+
+	var minPoint, maxPoint Point
+	first := true
+	for h := range grid.cells {
+		p := hexTextPosition(h, layout)
+		if first {
+			minPoint, maxPoint, first = p, p, false
+			continue
+		}
+		minPoint = Point{X: min(minPoint.X, p.X), Y: min(minPoint.Y, p.Y)}
+		maxPoint = Point{X: max(maxPoint.X, p.X), Y: max(maxPoint.Y, p.Y)}
+	}
+
+	lines := make([][]rune, maxPoint.Y-minPoint.Y+1)
+	for y := range lines {
+		lines[y] = []rune(strings.Repeat(" ", maxPoint.X-minPoint.X+1))
+	}
+	for h, cell := range grid.cells {
+		p := hexTextPosition(h, layout)
+		lines[p.Y-minPoint.Y][p.X-minPoint.X] = glyph(cell)
+	}
+
+	var sb strings.Builder
+	for _, line := range lines {
+		sb.WriteString(strings.TrimRight(string(line), " "))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// HexGridImage draws every set hexagon filled with its colour, size pixels from centre to corner.
+// Pixels outside the set hexagons are left transparent.
+func HexGridImage[TCell any](grid *HexGrid[TCell], layout HexLayout, size int, cellColor func(cell TCell) color.Color) *image.RGBA {
+	if len(grid.cells) == 0 {
+		return image.NewRGBA(image.Rect(0, 0, 0, 0))
+	}
+
+	radius := float64(size)
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for h := range grid.cells {
+		x, y := HexToPixel(h, layout, radius)
+		minX, minY = min(minX, x-radius), min(minY, y-radius)
+		maxX, maxY = max(maxX, x+radius), max(maxY, y+radius)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, int(math.Ceil(maxX-minX)), int(math.Ceil(maxY-minY))))
+	colors := make(map[Hex]color.Color, len(grid.cells))
+	for h, cell := range grid.cells {
+		colors[h] = cellColor(cell)
+	}
+
+	bounds := img.Bounds()
+	for py := bounds.Min.Y; py < bounds.Max.Y; py++ {
+		for px := bounds.Min.X; px < bounds.Max.X; px++ {
+			// Sample each pixel at its centre.
+			h := PixelToHex(minX+float64(px)+0.5, minY+float64(py)+0.5, layout, radius)
+			if c, ok := colors[h]; ok {
+				img.Set(px, py, c)
+			}
+		}
+	}
+
+	return img
+}
+
+func HexGridEncodePNG[TCell any](w io.Writer, grid *HexGrid[TCell], layout HexLayout, size int, cellColor func(cell TCell) color.Color) error {
+	return png.Encode(w, HexGridImage(grid, layout, size, cellColor))
+}
+
+// HexGridSavePNG writes the grid as a PNG file, creating parent directories as needed.
+func HexGridSavePNG[TCell any](path string, grid *HexGrid[TCell], layout HexLayout, size int, cellColor func(cell TCell) color.Color) error {
+	return writeImageFile(path, func(w io.Writer) error {
+		return HexGridEncodePNG(w, grid, layout, size, cellColor)
+	})
+}
@@ -0,0 +1,163 @@
+package aocshared
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// InputKeyEnv overrides the location of the input key file.
+const InputKeyEnv = "AOC_INPUT_KEY"
+
+// encryptedInputMagic prefixes every encrypted input so plaintext and ciphertext can share a file name.
+var encryptedInputMagic = []byte("AOCENC1\n")
+
+// InputKeyPath returns the key file location: $AOC_INPUT_KEY, or aoc/input.key in the user config dir.
+// The key deliberately lives outside the repository.
+func InputKeyPath() (string, error) {
+	if path := os.Getenv(InputKeyEnv); path != "" {
+		return path, nil
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config dir: %w", err)
+	}
+
+	return filepath.Join(configDir, "aoc", "input.key"), nil
+}
+
+// InputKeyLoad reads the hex encoded AES-256 key from InputKeyPath.
+func InputKeyLoad() ([]byte, error) {
+	path, err := InputKeyPath()
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input key: %w", err)
+	}
+
+	key, err := hex.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, fmt.Errorf("input key at %s is not valid hex: %w", path, err)
+	}
+
+	if len(key) != 32 {
+		return nil, fmt.Errorf("input key at %s must be 32 bytes, got %d", path, len(key))
+	}
+
+	return key, nil
+}
+
+// InputKeyGenerate writes a fresh random key to InputKeyPath, refusing to overwrite an existing one.
+func InputKeyGenerate() (string, error) {
+	path, err := InputKeyPath()
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("input key already exists at %s", path)
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", fmt.Errorf("failed to create key directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, []byte(hex.EncodeToString(key)+"\n"), 0600); err != nil {
+		return "", fmt.Errorf("failed to write key: %w", err)
+	}
+
+	return path, nil
+}
+
+// InputIsEncrypted reports whether data was produced by InputEncrypt.
+func InputIsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, encryptedInputMagic)
+}
+
+// InputEncrypt seals plaintext with AES-GCM. The output is magic || nonce || ciphertext.
+func InputEncrypt(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newInputCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	out := make([]byte, 0, len(encryptedInputMagic)+len(nonce)+len(plaintext)+gcm.Overhead())
+	out = append(out, encryptedInputMagic...)
+	out = append(out, nonce...)
+
+	return gcm.Seal(out, nonce, plaintext, encryptedInputMagic), nil
+}
+
+// InputDecrypt opens data produced by InputEncrypt.
+func InputDecrypt(key, data []byte) ([]byte, error) {
+	if !InputIsEncrypted(data) {
+		return nil, errors.New("input is not encrypted")
+	}
+
+	gcm, err := newInputCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	body := data[len(encryptedInputMagic):]
+	if len(body) < gcm.NonceSize() {
+		return nil, errors.New("encrypted input is truncated")
+	}
+
+	nonce, ciphertext := body[:gcm.NonceSize()], body[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, encryptedInputMagic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt input (wrong key or corrupted file): %w", err)
+	}
+
+	return plaintext, nil
+}
+
+// InputReadFile reads an input file, decrypting it first when it is stored encrypted.
+func InputReadFile(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !InputIsEncrypted(content) {
+		return content, nil
+	}
+
+	key, err := InputKeyLoad()
+	if err != nil {
+		return nil, err
+	}
+
+	return InputDecrypt(key, content)
+}
+
+func newInputCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input key: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
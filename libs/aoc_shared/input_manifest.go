@@ -0,0 +1,110 @@
+package aocshared
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// InputManifestFileName is the manifest kept next to the year folders in src.
+// Its format matches sha256sum, but the checksums are of the plaintext: `sha256sum -c inputs.sha256`
+// only works from src while the inputs are unencrypted. The runner verifies decrypted inputs either way.
+const InputManifestFileName = "inputs.sha256"
+
+// ErrInputUnrecorded is returned when the manifest has no checksum for an input.
+var ErrInputUnrecorded = errors.New("input has no recorded checksum")
+
+// InputManifest maps input paths (relative to the manifest) to their SHA-256 checksums.
+type InputManifest struct {
+	path    string
+	entries map[string]string
+}
+
+// InputManifestKey returns the manifest entry used for the input of the given puzzle.
+func InputManifestKey(year, day int) string {
+	return fmt.Sprintf("%d/day%d/input.txt", year, day)
+}
+
+// InputChecksum returns the hex encoded SHA-256 of the raw input bytes.
+func InputChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// InputManifestLoad reads the manifest at path. A missing file yields an empty manifest.
+func InputManifestLoad(path string) (*InputManifest, error) {
+	manifest := &InputManifest{
+		path:    path,
+		entries: make(map[string]string),
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		checksum, entry, found := strings.Cut(line, "  ")
+		if !found || len(checksum) != sha256.Size*2 {
+			return nil, fmt.Errorf("malformed manifest line %d: %q", lineNum, line)
+		}
+
+		manifest.entries[entry] = checksum
+	}
+
+	return manifest, scanner.Err()
+}
+
+// InputManifestRecord stores the checksum of data under entry. Call InputManifestSave to persist it.
+func InputManifestRecord(manifest *InputManifest, entry string, data []byte) {
+	manifest.entries[entry] = InputChecksum(data)
+}
+
+// InputManifestVerify compares data against the checksum recorded for entry.
+func InputManifestVerify(manifest *InputManifest, entry string, data []byte) error {
+	expected, ok := manifest.entries[entry]
+	if !ok {
+		return ErrInputUnrecorded
+	}
+
+	if actual := InputChecksum(data); actual != expected {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", entry, expected, actual)
+	}
+
+	return nil
+}
+
+// InputManifestSave writes the manifest back to disk with entries sorted by path.
+func InputManifestSave(manifest *InputManifest) error {
+	entries := make([]string, 0, len(manifest.entries))
+	for entry := range manifest.entries {
+		entries = append(entries, entry)
+	}
+	sort.Strings(entries)
+
+	var sb strings.Builder
+	for _, entry := range entries {
+		sb.WriteString(fmt.Sprintf("%s  %s\n", manifest.entries[entry], entry))
+	}
+
+	if err := os.WriteFile(manifest.path, []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package aocshared
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+)
+
+// MaxStreamLineLength bounds a single line when streaming; longer lines stop the scan with bufio.ErrTooLong.
+const MaxStreamLineLength = 16 * 1024 * 1024
+
+// InputLines streams the lines of a day's input without loading the whole file.
+// The file is opened when ranging starts and closed when ranging stops, so the sequence can be ranged again.
+// Line endings follow GetInput: CRLF is accepted and trailing blank lines are dropped.
+// The returned function reports the error that ended the last range early, if any.
+//
+// Encrypted inputs cannot be decrypted piecewise and are read into memory first.
+func InputLines(year, day int) (iter.Seq[string], func() error) {
+	byteLines, errFn := InputByteLines(year, day)
+
+	return func(yield func(string) bool) {
+		for _, line := range byteLines {
+			if !yield(string(line)) {
+				return
+			}
+		}
+	}, errFn
+}
+
+// InputByteLines streams a day's input like InputLines, yielding the 0-based line number and the line bytes.
+// The bytes are only valid until the next iteration; copy them to keep them.
+func InputByteLines(year, day int) (iter.Seq2[int, []byte], func() error) {
+	var err error
+
+	return func(yield func(int, []byte) bool) {
+		err = nil
+
+		var dir string
+		dir, err = PuzzleDir(year, day)
+		if err != nil {
+			return
+		}
+
+		path := filepath.Join(dir, "input.txt")
+		file, openErr := os.Open(path)
+		if openErr != nil {
+			err = fmt.Errorf("failed to read input.txt for %d day %d: %w", year, day, openErr)
+			return
+		}
+		defer file.Close()
+
+		var reader io.Reader
+		reader, err = decryptingReader(bufio.NewReader(file))
+		if err != nil {
+			err = fmt.Errorf("failed to read input.txt for %d day %d: %w", year, day, err)
+			return
+		}
+
+		err = scanByteLines(reader, yield)
+	}, func() error { return err }
+}
+
+// ScanLines streams the lines of any reader with the same rules as InputLines.
+// The sequence reads from r directly and is meant to be ranged once.
+func ScanLines(r io.Reader) (iter.Seq[string], func() error) {
+	var err error
+
+	return func(yield func(string) bool) {
+		err = scanByteLines(r, func(_ int, line []byte) bool {
+			return yield(string(line))
+		})
+	}, func() error { return err }
+}
+
+// ScanByteLines is ScanLines yielding line numbers and reused line buffers, as InputByteLines does.
+func ScanByteLines(r io.Reader) (iter.Seq2[int, []byte], func() error) {
+	var err error
+
+	return func(yield func(int, []byte) bool) {
+		err = scanByteLines(r, yield)
+	}, func() error { return err }
+}
+
+// decryptingReader passes plaintext through untouched and decrypts encrypted inputs up front.
+func decryptingReader(r *bufio.Reader) (io.Reader, error) {
+	head, err := r.Peek(len(encryptedInputMagic))
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+
+	if !InputIsEncrypted(head) {
+		return r, nil
+	}
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := InputKeyLoad()
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := InputDecrypt(key, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(plaintext), nil
+}
+
+// scanByteLines yields each line, holding back runs of empty lines until a non-empty one follows,
+// so trailing blank lines are dropped without buffering their content.
+func scanByteLines(r io.Reader, yield func(int, []byte) bool) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxStreamLineLength)
+
+	lineNum := 0
+	pendingEmpty := 0
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			pendingEmpty++
+			continue
+		}
+
+		for ; pendingEmpty > 0; pendingEmpty-- {
+			if !yield(lineNum, []byte{}) {
+				return nil
+			}
+			lineNum++
+		}
+
+		if !yield(lineNum, line) {
+			return nil
+		}
+		lineNum++
+	}
+
+	return scanner.Err()
+}
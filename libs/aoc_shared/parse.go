@@ -0,0 +1,305 @@
+package aocshared
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseError points at the offending spot of the input. Line and Column are 1-based;
+// Line is 0 when a single line was parsed on its own.
+type ParseError struct {
+	Line   int
+	Column int
+	Msg    string
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	position := fmt.Sprintf("column %d", e.Column)
+	if e.Line > 0 {
+		position = fmt.Sprintf("line %d, column %d", e.Line, e.Column)
+	}
+
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", position, e.Msg, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", position, e.Msg)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Range is an inclusive a-b pair.
+type Range struct {
+	Start int
+	End   int
+}
+
+// parsedLine is a trimmed line that remembers where it came from, so errors can point back into the input.
+type parsedLine struct {
+	num    int
+	offset int
+	text   string
+}
+
+func splitLines(input string) []string {
+	return strings.Split(strings.ReplaceAll(input, "\r\n", "\n"), "\n")
+}
+
+func nonEmptyLines(input string) []parsedLine {
+	lines := make([]parsedLine, 0)
+	for i, raw := range splitLines(input) {
+		trimmed := strings.TrimSpace(raw)
+		if trimmed == "" {
+			continue
+		}
+
+		lines = append(lines, parsedLine{
+			num:    i + 1,
+			offset: strings.Index(raw, trimmed),
+			text:   trimmed,
+		})
+	}
+	return lines
+}
+
+// atLine moves a single-line ParseError to its place in the whole input.
+func atLine(err error, line parsedLine) error {
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		return &ParseError{Line: line.num, Column: line.offset + 1, Msg: "invalid line", Err: err}
+	}
+
+	located := *parseErr
+	located.Line = line.num
+	located.Column += line.offset
+	return &located
+}
+
+// ParseLines returns the trimmed, non-empty lines of the input.
+func ParseLines(input string) []string {
+	lines := nonEmptyLines(input)
+	texts := make([]string, len(lines))
+	for i, line := range lines {
+		texts[i] = line.text
+	}
+	return texts
+}
+
+// ParseBlocks splits the input on blank lines. Lines inside a block are kept untrimmed.
+func ParseBlocks(input string) [][]string {
+	blocks, _ := ParseBlocksNumbered(input)
+	return blocks
+}
+
+// ParseBlocksNumbered is ParseBlocks that also returns the 1-based line each block starts on,
+// so errors from parsing a block on its own can be moved back with ParseErrorInBlock.
+func ParseBlocksNumbered(input string) ([][]string, []int) {
+	blocks := make([][]string, 0)
+	startLines := make([]int, 0)
+	current := make([]string, 0)
+
+	for i, line := range splitLines(input) {
+		if strings.TrimSpace(line) == "" {
+			if len(current) > 0 {
+				blocks = append(blocks, current)
+				current = make([]string, 0)
+			}
+			continue
+		}
+
+		if len(current) == 0 {
+			startLines = append(startLines, i+1)
+		}
+		current = append(current, line)
+	}
+
+	if len(current) > 0 {
+		blocks = append(blocks, current)
+	}
+
+	return blocks, startLines
+}
+
+// ParseErrorInBlock moves a ParseError from a block parsed on its own to its line in the whole input.
+// Other errors are returned unchanged.
+func ParseErrorInBlock(err error, startLine int) error {
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line == 0 {
+		return err
+	}
+
+	located := *parseErr
+	located.Line += startLine - 1
+	return &located
+}
+
+// ParseInts extracts every signed integer of a line in order: "x=-3, y=12" gives [-3 12].
+// A '-' only counts as a sign when it does not follow a digit, so "10-20" gives [10 20].
+func ParseInts(line string) ([]int, error) {
+	values := make([]int, 0)
+
+	for i := 0; i < len(line); {
+		start := i
+		if line[i] == '-' && i+1 < len(line) && isDigit(line[i+1]) && (i == 0 || !isDigit(line[i-1])) {
+			i++
+		}
+
+		if !isDigit(line[i]) {
+			i++
+			continue
+		}
+
+		for i < len(line) && isDigit(line[i]) {
+			i++
+		}
+
+		value, err := strconv.Atoi(line[start:i])
+		if err != nil {
+			return nil, &ParseError{Column: start + 1, Msg: fmt.Sprintf("invalid integer %q", line[start:i]), Err: err}
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// ParseIntLines applies ParseInts to every non-empty line.
+func ParseIntLines(input string) ([][]int, error) {
+	lines := nonEmptyLines(input)
+	result := make([][]int, len(lines))
+
+	for i, line := range lines {
+		values, err := ParseInts(line.text)
+		if err != nil {
+			return nil, atLine(err, line)
+		}
+		result[i] = values
+	}
+
+	return result, nil
+}
+
+// ParseRange reads an "a-b" pair. Either bound may be negative: "-5--2".
+func ParseRange(s string) (Range, error) {
+	startEnd := scanSignedInt(s, 0)
+	if startEnd == 0 {
+		return Range{}, &ParseError{Column: 1, Msg: fmt.Sprintf("expected a range start in %q", s)}
+	}
+
+	if startEnd >= len(s) || s[startEnd] != '-' {
+		return Range{}, &ParseError{Column: startEnd + 1, Msg: fmt.Sprintf("expected '-' in %q", s)}
+	}
+
+	endEnd := scanSignedInt(s, startEnd+1)
+	if endEnd == startEnd+1 || endEnd != len(s) {
+		return Range{}, &ParseError{Column: startEnd + 2, Msg: fmt.Sprintf("expected a range end in %q", s)}
+	}
+
+	start, err := strconv.Atoi(s[:startEnd])
+	if err != nil {
+		return Range{}, &ParseError{Column: 1, Msg: "invalid range start", Err: err}
+	}
+
+	end, err := strconv.Atoi(s[startEnd+1:])
+	if err != nil {
+		return Range{}, &ParseError{Column: startEnd + 2, Msg: "invalid range end", Err: err}
+	}
+
+	return Range{Start: start, End: end}, nil
+}
+
+// ParseRanges reads a-b pairs separated by commas and/or newlines, as in "1-3,5-7" or one per line.
+func ParseRanges(input string) ([]Range, error) {
+	ranges := make([]Range, 0)
+
+	for _, line := range nonEmptyLines(input) {
+		column := 0
+		for _, field := range strings.Split(line.text, ",") {
+			trimmed := strings.TrimSpace(field)
+			fieldOffset := column + strings.Index(field, trimmed)
+			column += len(field) + 1
+
+			if trimmed == "" {
+				continue
+			}
+
+			r, err := ParseRange(trimmed)
+			if err != nil {
+				var parseErr *ParseError
+				errors.As(err, &parseErr)
+				parseErr.Column += fieldOffset
+				return nil, atLine(parseErr, line)
+			}
+			ranges = append(ranges, r)
+		}
+	}
+
+	return ranges, nil
+}
+
+// ParseTuple reads exactly n comma-separated integers, as in "3,-4,5".
+func ParseTuple(s string, n int) ([]int, error) {
+	fields := strings.Split(s, ",")
+	if len(fields) != n {
+		return nil, &ParseError{Column: 1, Msg: fmt.Sprintf("expected %d comma-separated values, got %d in %q", n, len(fields), s)}
+	}
+
+	values := make([]int, n)
+	column := 1
+	for i, field := range fields {
+		trimmed := strings.TrimSpace(field)
+
+		value, err := strconv.Atoi(trimmed)
+		if err != nil {
+			return nil, &ParseError{Column: column + strings.Index(field, trimmed), Msg: fmt.Sprintf("invalid integer %q", trimmed), Err: err}
+		}
+
+		values[i] = value
+		column += len(field) + 1
+	}
+
+	return values, nil
+}
+
+// ParseTuples applies ParseTuple to every non-empty line.
+func ParseTuples(input string, n int) ([][]int, error) {
+	lines := nonEmptyLines(input)
+	tuples := make([][]int, len(lines))
+
+	for i, line := range lines {
+		tuple, err := ParseTuple(line.text, n)
+		if err != nil {
+			return nil, atLine(err, line)
+		}
+		tuples[i] = tuple
+	}
+
+	return tuples, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// scanSignedInt returns the index just past an optionally signed run of digits starting at from,
+// or from itself when there is none.
+func scanSignedInt(s string, from int) int {
+	i := from
+	if i < len(s) && s[i] == '-' {
+		i++
+	}
+
+	digitsStart := i
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+
+	if i == digitsStart {
+		return from
+	}
+	return i
+}
@@ -0,0 +1,250 @@
+package aocshared
+
+import (
+	"container/heap"
+	"iter"
+)
+
+// Searches run over any comparable state: a Point for plain grid walks, or a struct such as
+// position+direction when the rules depend on more than the position. next yields the states
+// reachable from a state with the cost of each step.
+
+// PathResult holds the outcome of a search.
+type PathResult[S comparable] struct {
+	Found    bool // a target was reached; always false when no target predicate was given
+	Target   S
+	Distance int // cost from the nearest source to Target, or -1 when nothing was found
+	Path     []S // sources first, Target last
+
+	// Dist is the distance field: the cost of every state reached. Without a target it covers all
+	// reachable states; with one, the search stops early, so states past the target may be missing
+	// or hold a cost that is not yet final.
+	Dist map[S]int
+
+	prev map[S]S
+}
+
+// PathTo rebuilds the path from the nearest source to a reached state, or returns nil.
+func (r *PathResult[S]) PathTo(state S) []S {
+	if _, ok := r.Dist[state]; !ok {
+		return nil
+	}
+
+	path := []S{state}
+	for {
+		previous, ok := r.prev[state]
+		if !ok {
+			break
+		}
+		path = append(path, previous)
+		state = previous
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+func newPathResult[S comparable]() *PathResult[S] {
+	return &PathResult[S]{
+		Distance: -1,
+		Dist:     make(map[S]int),
+		prev:     make(map[S]S),
+	}
+}
+
+func (r *PathResult[S]) finish(target S) *PathResult[S] {
+	r.Found = true
+	r.Target = target
+	r.Distance = r.Dist[target]
+	r.Path = r.PathTo(target)
+	return r
+}
+
+// PathBFS explores in order of step count, ignoring step costs. isTarget may be nil to explore everything.
+func PathBFS[S comparable](sources []S, next func(S) iter.Seq2[S, int], isTarget func(S) bool) *PathResult[S] {
+	result := newPathResult[S]()
+	queue := make([]S, 0, len(sources))
+
+	for _, source := range sources {
+		if _, seen := result.Dist[source]; !seen {
+			result.Dist[source] = 0
+			queue = append(queue, source)
+		}
+	}
+
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+
+		if isTarget != nil && isTarget(state) {
+			return result.finish(state)
+		}
+
+		for neighbour := range next(state) {
+			if _, seen := result.Dist[neighbour]; seen {
+				continue
+			}
+			result.Dist[neighbour] = result.Dist[state] + 1
+			result.prev[neighbour] = state
+			queue = append(queue, neighbour)
+		}
+	}
+
+	return result
+}
+
+// PathDijkstra finds cheapest paths for non-negative step costs.
+func PathDijkstra[S comparable](sources []S, next func(S) iter.Seq2[S, int], isTarget func(S) bool) *PathResult[S] {
+	return PathAStar(sources, next, isTarget, nil)
+}
+
+// PathAStar is Dijkstra guided by a heuristic. The heuristic must be consistent: it never overestimates
+// the remaining cost and drops by at most the cost of a step. A nil heuristic makes it plain Dijkstra.
+func PathAStar[S comparable](sources []S, next func(S) iter.Seq2[S, int], isTarget func(S) bool, heuristic func(S) int) *PathResult[S] {
+	result := newPathResult[S]()
+	settled := make(map[S]bool)
+	queue := &pathQueue[S]{}
+
+	estimate := func(state S, dist int) int {
+		if heuristic == nil {
+			return dist
+		}
+		return dist + heuristic(state)
+	}
+
+	for _, source := range sources {
+		if _, seen := result.Dist[source]; !seen {
+			result.Dist[source] = 0
+			heap.Push(queue, pathQueueItem[S]{state: source, dist: 0, priority: estimate(source, 0)})
+		}
+	}
+
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(pathQueueItem[S])
+		if settled[item.state] || item.dist > result.Dist[item.state] {
+			continue
+		}
+		settled[item.state] = true
+
+		if isTarget != nil && isTarget(item.state) {
+			return result.finish(item.state)
+		}
+
+		for neighbour, cost := range next(item.state) {
+			dist := item.dist + cost
+			if known, seen := result.Dist[neighbour]; seen && known <= dist {
+				continue
+			}
+
+			result.Dist[neighbour] = dist
+			result.prev[neighbour] = item.state
+			heap.Push(queue, pathQueueItem[S]{state: neighbour, dist: dist, priority: estimate(neighbour, dist)})
+		}
+	}
+
+	return result
+}
+
+type pathQueueItem[S comparable] struct {
+	state    S
+	dist     int
+	priority int
+}
+
+type pathQueue[S comparable] []pathQueueItem[S]
+
+func (q pathQueue[S]) Len() int           { return len(q) }
+func (q pathQueue[S]) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q pathQueue[S]) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *pathQueue[S]) Push(x any)        { *q = append(*q, x.(pathQueueItem[S])) }
+func (q *pathQueue[S]) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// GridPathOptions describes how to walk a grid. Nil functions mean every step is allowed and costs 1.
+type GridPathOptions[TCell any] struct {
+	Conn     Connectivity
+	Passable func(from, to Point, cell TCell) bool
+	Cost     func(from, to Point, cell TCell) int
+}
+
+// GridPathNext turns a grid and its walking rules into the next function the searches take.
+// It steps wherever the grid's Neighbors go, so it follows a Grid's wrap mode and stays inside a GridView.
+// A SparseGrid has no edge: Passable or the target has to keep the search from running off forever.
+func GridPathNext[TCell any](grid CellGrid[TCell], options GridPathOptions[TCell]) func(Point) iter.Seq2[Point, int] {
+	return func(from Point) iter.Seq2[Point, int] {
+		return func(yield func(Point, int) bool) {
+			for to := range grid.Neighbors(from, options.Conn) {
+				cell, _ := grid.At(to.X, to.Y)
+				if options.Passable != nil && !options.Passable(from, to, cell) {
+					continue
+				}
+
+				cost := 1
+				if options.Cost != nil {
+					cost = options.Cost(from, to, cell)
+				}
+
+				if !yield(to, cost) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func GridBFS[TCell any](grid CellGrid[TCell], sources []Point, isTarget func(Point) bool, options GridPathOptions[TCell]) *PathResult[Point] {
+	return PathBFS(sources, GridPathNext(grid, options), isTarget)
+}
+
+func GridDijkstra[TCell any](grid CellGrid[TCell], sources []Point, isTarget func(Point) bool, options GridPathOptions[TCell]) *PathResult[Point] {
+	return PathDijkstra(sources, GridPathNext(grid, options), isTarget)
+}
+
+func GridAStar[TCell any](grid CellGrid[TCell], sources []Point, isTarget func(Point) bool, heuristic func(Point) int, options GridPathOptions[TCell]) *PathResult[Point] {
+	return PathAStar(sources, GridPathNext(grid, options), isTarget, heuristic)
+}
+
+// GridDistanceField lays a search's distances over the grid it ran on; unreached cells hold -1.
+// The field covers the grid's bounds with the bounds' minimum at (0, 0), which only moves anything for
+// a SparseGrid; points a search reached outside those bounds are left out. A Grid's wrap mode is kept.
+func GridDistanceField[TCell any](grid CellGrid[TCell], result *PathResult[Point]) *Grid[int] {
+	minPoint, maxPoint := grid.Bounds()
+	width, height := max(maxPoint.X-minPoint.X+1, 0), max(maxPoint.Y-minPoint.Y+1, 0)
+
+	field := &Grid[int]{
+		cells:     make([]int, width*height),
+		width:     width,
+		height:    height,
+		queuedOps: make([]func(), 0),
+	}
+	if dense, ok := grid.(*Grid[TCell]); ok {
+		field.wrap = dense.wrap
+	}
+
+	for i := range field.cells {
+		field.cells[i] = -1
+	}
+	for p, dist := range result.Dist {
+		field.Set(p.X-minPoint.X, p.Y-minPoint.Y, dist)
+	}
+
+	return field
+}
+
+// ManhattanDistance is the usual A* heuristic for 4-connected grids with unit steps.
+func ManhattanDistance(a, b Point) int {
+	return abs(a.X-b.X) + abs(a.Y-b.Y)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,499 @@
+package aocshared
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Patterns describe a line as literal text mixed with {Field} captures:
+//
+//	\[{Diagram}\] {Buttons:(...)+} \{{Joltage:ints}\}
+//
+//   - {Field} captures text into the struct field of that name, converted by the field's type:
+//     strings, ints, uints, floats, bools, slices (split on commas, else whitespace),
+//     encoding.TextUnmarshaler, and structs whose field carries a `pattern:"..."` tag.
+//   - {Field:ints} extracts every signed integer of the capture into an int slice.
+//   - {Field:ITEM+} captures one or more ITEMs, optionally separated by whitespace, into a slice.
+//     Inside ITEM, "..." marks the value of each element: (...)+ reads "(1,3) (2)" as ["1,3", "2"].
+//   - A run of whitespace matches any non-empty run of whitespace.
+//   - A backslash makes the next character literal, so \{ and \[ match braces and brackets.
+
+type patternSegment struct {
+	literal string
+
+	field    string
+	spec     string
+	repeated bool
+	item     *regexp.Regexp // extracts each repeated element; submatch 1 is its value
+}
+
+type compiledPattern struct {
+	segments []patternSegment
+	full     *regexp.Regexp
+	prefixes []*regexp.Regexp // prefixes[k] matches segments[:k+1] from the start of the line
+}
+
+var patternCache sync.Map
+
+// Unmarshal decodes every line into a new element of the slice out points to.
+// Errors are ParseErrors whose Line is the 1-based index into lines.
+func Unmarshal(lines []string, out any, pattern string) error {
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Pointer || target.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("unmarshal target must be a pointer to a slice, got %T", out)
+	}
+
+	compiled, err := compilePattern(pattern)
+	if err != nil {
+		return err
+	}
+
+	slice := target.Elem()
+	for i, line := range lines {
+		elem := reflect.New(slice.Type().Elem()).Elem()
+		if err := compiled.decode(line, elem); err != nil {
+			return withLineNumber(err, i+1)
+		}
+		slice.Set(reflect.Append(slice, elem))
+	}
+
+	return nil
+}
+
+// UnmarshalLine decodes a single line into the struct out points to.
+func UnmarshalLine(line string, out any, pattern string) error {
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return fmt.Errorf("unmarshal target must be a non-nil pointer, got %T", out)
+	}
+
+	compiled, err := compilePattern(pattern)
+	if err != nil {
+		return err
+	}
+
+	return compiled.decode(line, target.Elem())
+}
+
+func withLineNumber(err error, line int) error {
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		return &ParseError{Line: line, Column: 1, Msg: "invalid line", Err: err}
+	}
+
+	located := *parseErr
+	located.Line = line
+	return &located
+}
+
+func compilePattern(pattern string) (*compiledPattern, error) {
+	if cached, ok := patternCache.Load(pattern); ok {
+		return cached.(*compiledPattern), nil
+	}
+
+	segments, err := splitPattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	compiled := &compiledPattern{segments: segments}
+	fragments := make([]string, len(segments))
+
+	for i := range segments {
+		fragment, err := segmentRegex(&segments[i])
+		if err != nil {
+			return nil, fmt.Errorf("pattern %q: %w", pattern, err)
+		}
+		fragments[i] = fragment
+
+		prefix, err := regexp.Compile("^" + strings.Join(fragments[:i+1], ""))
+		if err != nil {
+			return nil, fmt.Errorf("pattern %q: %w", pattern, err)
+		}
+		compiled.prefixes = append(compiled.prefixes, prefix)
+	}
+
+	compiled.full, err = regexp.Compile("^" + strings.Join(fragments, "") + "$")
+	if err != nil {
+		return nil, fmt.Errorf("pattern %q: %w", pattern, err)
+	}
+
+	patternCache.Store(pattern, compiled)
+	return compiled, nil
+}
+
+// splitPattern separates literal text from {Field[:spec]} captures, resolving backslash escapes in literals.
+func splitPattern(pattern string) ([]patternSegment, error) {
+	segments := make([]patternSegment, 0)
+	var literal strings.Builder
+
+	flushLiteral := func() {
+		if literal.Len() > 0 {
+			segments = append(segments, patternSegment{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			if i+1 >= len(pattern) {
+				return nil, fmt.Errorf("pattern %q ends with a lone backslash", pattern)
+			}
+			i++
+			literal.WriteByte(pattern[i])
+		case '{':
+			end := captureEnd(pattern, i)
+			if end < 0 {
+				return nil, fmt.Errorf("pattern %q: unclosed '{' at column %d", pattern, i+1)
+			}
+
+			flushLiteral()
+
+			name, spec, _ := strings.Cut(pattern[i+1:end], ":")
+			segment := patternSegment{field: name, spec: spec}
+			if strings.HasSuffix(spec, "+") {
+				segment.repeated = true
+				segment.spec = strings.TrimSuffix(spec, "+")
+			}
+			segments = append(segments, segment)
+
+			i = end
+		default:
+			literal.WriteByte(pattern[i])
+		}
+	}
+
+	flushLiteral()
+	return segments, nil
+}
+
+// captureEnd finds the '}' closing the capture opened at start, skipping escaped characters.
+func captureEnd(pattern string, start int) int {
+	for i := start + 1; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '}':
+			return i
+		}
+	}
+	return -1
+}
+
+var (
+	whitespaceRun = regexp.MustCompile(`\s+`)
+	nonSpaceRun   = regexp.MustCompile(`\S+`)
+	nonSpaceItem  = regexp.MustCompile(`(\S+)`)
+)
+
+// literalRegex quotes literal text, letting whitespace runs match any whitespace run.
+func literalRegex(literal string) string {
+	parts := whitespaceRun.Split(literal, -1)
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return strings.Join(parts, `\s+`)
+}
+
+// itemRegex turns an item pattern such as `(...)` into a regex, with valueGroup standing in for "...".
+func itemRegex(item, valueGroup string) (string, error) {
+	before, after, found := strings.Cut(item, "...")
+	if !found {
+		return "", fmt.Errorf("repeated item %q has no '...' placeholder", item)
+	}
+
+	return literalRegex(unescape(before)) + valueGroup + literalRegex(unescape(after)), nil
+}
+
+func unescape(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
+
+func segmentRegex(segment *patternSegment) (string, error) {
+	if segment.field == "" {
+		return literalRegex(segment.literal), nil
+	}
+
+	if !segment.repeated {
+		return `(.*?)`, nil
+	}
+
+	// A bare "..." item is a whitespace separated token.
+	if segment.spec == "..." {
+		segment.item = nonSpaceItem
+		return `(\S+(?:\s+\S+)*?)`, nil
+	}
+
+	inner, err := itemRegex(segment.spec, `.*?`)
+	if err != nil {
+		return "", err
+	}
+
+	extract, err := itemRegex(segment.spec, `(.*?)`)
+	if err != nil {
+		return "", err
+	}
+
+	segment.item, err = regexp.Compile(extract)
+	if err != nil {
+		return "", err
+	}
+
+	return `((?:` + inner + `)(?:\s*(?:` + inner + `))*)`, nil
+}
+
+func (p *compiledPattern) decode(line string, target reflect.Value) error {
+	for target.Kind() == reflect.Pointer {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		target = target.Elem()
+	}
+
+	if target.Kind() != reflect.Struct {
+		return fmt.Errorf("pattern target must be a struct, got %s", target.Type())
+	}
+
+	match := p.full.FindStringSubmatchIndex(line)
+	if match == nil {
+		return p.mismatch(line)
+	}
+
+	group := 1
+	for _, segment := range p.segments {
+		if segment.field == "" {
+			continue
+		}
+
+		start, end := match[2*group], match[2*group+1]
+		group++
+
+		field, ok := target.Type().FieldByName(segment.field)
+		if !ok || !field.IsExported() {
+			return fmt.Errorf("pattern field %q is not an exported field of %s", segment.field, target.Type())
+		}
+
+		value := target.FieldByIndex(field.Index)
+		text := line[start:end]
+
+		var err error
+		if segment.repeated {
+			err = decodeRepeated(segment, text, value, field.Tag.Get("pattern"))
+		} else {
+			err = decodeValue(text, segment.spec, value, field.Tag.Get("pattern"))
+		}
+
+		if err != nil {
+			return fieldError(err, segment.field, start)
+		}
+	}
+
+	return nil
+}
+
+// mismatch explains where a line stops following the pattern, using the longest matching prefix.
+func (p *compiledPattern) mismatch(line string) error {
+	matched := -1
+	column := 0
+	for k, prefix := range p.prefixes {
+		loc := prefix.FindStringIndex(line)
+		if loc == nil {
+			break
+		}
+		matched = k
+		column = loc[1]
+	}
+
+	if matched == len(p.segments)-1 {
+		return &ParseError{Column: column + 1, Msg: fmt.Sprintf("unexpected trailing text %q", line[column:])}
+	}
+
+	next := p.segments[matched+1]
+	if next.field != "" {
+		return &ParseError{Column: column + 1, Msg: fmt.Sprintf("expected a value for field %s", next.field)}
+	}
+
+	return &ParseError{Column: column + 1, Msg: fmt.Sprintf("expected %q", next.literal)}
+}
+
+// fieldError attaches the field name and moves the column to the capture's place in the line.
+func fieldError(err error, field string, offset int) error {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		located := *parseErr
+		located.Column += offset
+		located.Msg = fmt.Sprintf("field %s: %s", field, parseErr.Msg)
+		return &located
+	}
+
+	return &ParseError{Column: offset + 1, Msg: fmt.Sprintf("field %s", field), Err: err}
+}
+
+func decodeRepeated(segment patternSegment, text string, value reflect.Value, nestedPattern string) error {
+	if value.Kind() != reflect.Slice {
+		return &ParseError{Column: 1, Msg: fmt.Sprintf("repeated capture needs a slice, got %s", value.Type())}
+	}
+
+	items := segment.item.FindAllStringSubmatchIndex(text, -1)
+	slice := reflect.MakeSlice(value.Type(), 0, len(items))
+
+	for _, item := range items {
+		elem := reflect.New(value.Type().Elem()).Elem()
+		if err := decodeValue(text[item[2]:item[3]], "", elem, nestedPattern); err != nil {
+			return shiftColumn(err, item[2])
+		}
+		slice = reflect.Append(slice, elem)
+	}
+
+	value.Set(slice)
+	return nil
+}
+
+func shiftColumn(err error, offset int) error {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		located := *parseErr
+		located.Column += offset
+		return &located
+	}
+	return &ParseError{Column: offset + 1, Msg: "invalid value", Err: err}
+}
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// decodeValue converts captured text into value. Columns in returned errors are relative to text.
+func decodeValue(text, spec string, value reflect.Value, nestedPattern string) error {
+	if value.Kind() != reflect.Pointer && value.Addr().Type().Implements(textUnmarshalerType) {
+		if err := value.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+			return &ParseError{Column: 1, Msg: fmt.Sprintf("invalid %s %q", value.Type(), text), Err: err}
+		}
+		return nil
+	}
+
+	if spec == "ints" {
+		return decodeInts(text, value)
+	}
+	if spec != "" {
+		return &ParseError{Column: 1, Msg: fmt.Sprintf("unknown conversion %q", spec)}
+	}
+
+	trimmed := strings.TrimSpace(text)
+	column := strings.Index(text, trimmed) + 1
+	invalid := func(err error) error {
+		return &ParseError{Column: column, Msg: fmt.Sprintf("invalid %s %q", value.Type(), trimmed), Err: err}
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(text)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(trimmed, 10, value.Type().Bits())
+		if err != nil {
+			return invalid(err)
+		}
+		value.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(trimmed, 10, value.Type().Bits())
+		if err != nil {
+			return invalid(err)
+		}
+		value.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(trimmed, value.Type().Bits())
+		if err != nil {
+			return invalid(err)
+		}
+		value.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(trimmed)
+		if err != nil {
+			return invalid(err)
+		}
+		value.SetBool(b)
+	case reflect.Slice:
+		return decodeList(text, value, nestedPattern)
+	case reflect.Pointer:
+		value.Set(reflect.New(value.Type().Elem()))
+		return decodeValue(text, spec, value.Elem(), nestedPattern)
+	case reflect.Struct:
+		if nestedPattern == "" {
+			return &ParseError{Column: 1, Msg: fmt.Sprintf("%s needs a `pattern` tag to be decoded", value.Type())}
+		}
+
+		compiled, err := compilePattern(nestedPattern)
+		if err != nil {
+			return err
+		}
+		return compiled.decode(text, value)
+	default:
+		return &ParseError{Column: 1, Msg: fmt.Sprintf("unsupported field type %s", value.Type())}
+	}
+
+	return nil
+}
+
+// decodeList splits on commas when there are any, otherwise on whitespace, and decodes each piece.
+func decodeList(text string, value reflect.Value, nestedPattern string) error {
+	type piece struct {
+		text   string
+		offset int
+	}
+
+	pieces := make([]piece, 0)
+	if strings.Contains(text, ",") {
+		offset := 0
+		for _, part := range strings.Split(text, ",") {
+			pieces = append(pieces, piece{text: part, offset: offset})
+			offset += len(part) + 1
+		}
+	} else {
+		for _, loc := range nonSpaceRun.FindAllStringIndex(text, -1) {
+			pieces = append(pieces, piece{text: text[loc[0]:loc[1]], offset: loc[0]})
+		}
+	}
+
+	slice := reflect.MakeSlice(value.Type(), 0, len(pieces))
+	for _, p := range pieces {
+		elem := reflect.New(value.Type().Elem()).Elem()
+		if err := decodeValue(p.text, "", elem, nestedPattern); err != nil {
+			return shiftColumn(err, p.offset)
+		}
+		slice = reflect.Append(slice, elem)
+	}
+
+	value.Set(slice)
+	return nil
+}
+
+func decodeInts(text string, value reflect.Value) error {
+	if value.Kind() != reflect.Slice || !value.Type().Elem().ConvertibleTo(reflect.TypeFor[int]()) {
+		return &ParseError{Column: 1, Msg: fmt.Sprintf("ints conversion needs an integer slice, got %s", value.Type())}
+	}
+
+	ints, err := ParseInts(text)
+	if err != nil {
+		return err
+	}
+
+	slice := reflect.MakeSlice(value.Type(), len(ints), len(ints))
+	for i, n := range ints {
+		slice.Index(i).Set(reflect.ValueOf(n).Convert(value.Type().Elem()))
+	}
+
+	value.Set(slice)
+	return nil
+}
@@ -0,0 +1,185 @@
+package aocshared
+
+// Region is one connected component of a grid.
+type Region[TCell any] struct {
+	Label int   // value of the region's cells in the label grid, also its index in the region list
+	Cell  TCell // cell value of the first cell found, in reading order
+	Start Point // first cell found, in reading order
+
+	Area      int
+	Perimeter int // cell edges facing outside the region
+	Sides     int // straight fence runs, counting inner holes too
+	Corners   int
+
+	Min Point // bounding box, inclusive
+	Max Point
+}
+
+// GridFloodFill returns the cells connected to start through neighbours that same considers equal,
+// in the order they were reached. Nothing is returned when start is outside the grid.
+// The fill stays within the grid's bounds, so on a SparseGrid it covers at most the box around the set cells.
+func GridFloodFill[TCell any](grid CellGrid[TCell], start Point, conn Connectivity, same func(a, b TCell) bool) []Point {
+	if !grid.InBounds(start.X, start.Y) {
+		return nil
+	}
+
+	area := regionAreaCreate(grid)
+	return floodFill(grid, area, area.wrapInside(start), conn, same)
+}
+
+// regionArea indexes the cells inside a grid's bounds, which flood fills never leave.
+type regionArea struct {
+	origin  Point
+	width   int
+	height  int
+	visited []bool
+}
+
+func regionAreaCreate[TCell any](grid CellGrid[TCell]) *regionArea {
+	minPoint, maxPoint := grid.Bounds()
+	width, height := max(maxPoint.X-minPoint.X+1, 0), max(maxPoint.Y-minPoint.Y+1, 0)
+
+	return &regionArea{
+		origin:  minPoint,
+		width:   width,
+		height:  height,
+		visited: make([]bool, width*height),
+	}
+}
+
+func (area *regionArea) index(p Point) int {
+	return (p.Y-area.origin.Y)*area.width + p.X - area.origin.X
+}
+
+func (area *regionArea) contains(p Point) bool {
+	return p.X >= area.origin.X && p.Y >= area.origin.Y && p.X < area.origin.X+area.width && p.Y < area.origin.Y+area.height
+}
+
+// wrapInside brings a point a wrapping grid accepts from beyond its edge back inside the bounds.
+func (area *regionArea) wrapInside(p Point) Point {
+	return Point{
+		X: area.origin.X + WrapIndex(p.X-area.origin.X, area.width),
+		Y: area.origin.Y + WrapIndex(p.Y-area.origin.Y, area.height),
+	}
+}
+
+func floodFill[TCell any](grid CellGrid[TCell], area *regionArea, start Point, conn Connectivity, same func(a, b TCell) bool) []Point {
+	area.visited[area.index(start)] = true
+	region := []Point{start}
+
+	for i := 0; i < len(region); i++ {
+		current := region[i]
+		cell, _ := grid.At(current.X, current.Y)
+
+		for next := range grid.Neighbors(current, conn) {
+			if !area.contains(next) || area.visited[area.index(next)] {
+				continue
+			}
+
+			if other, _ := grid.At(next.X, next.Y); !same(cell, other) {
+				continue
+			}
+
+			area.visited[area.index(next)] = true
+			region = append(region, next)
+		}
+	}
+
+	return region
+}
+
+// GridLabelComponents splits the grid into connected regions of cells that same considers equal.
+// The returned label grid holds each cell's region index and covers the grid's bounds with their minimum
+// at (0, 0), which only moves anything for a SparseGrid; it keeps a Grid's wrap mode. Regions report
+// their points in the input's coordinates, and on a SparseGrid the unset cells inside the bounds are
+// labelled as the fill value.
+func GridLabelComponents[TCell any](grid CellGrid[TCell], conn Connectivity, same func(a, b TCell) bool) (*Grid[int], []Region[TCell]) {
+	area := regionAreaCreate(grid)
+
+	labels := &Grid[int]{
+		cells:     make([]int, area.width*area.height),
+		width:     area.width,
+		height:    area.height,
+		queuedOps: make([]func(), 0),
+	}
+	if dense, ok := grid.(*Grid[TCell]); ok {
+		labels.wrap = dense.wrap
+	}
+
+	regions := make([]Region[TCell], 0)
+
+	for i := range area.visited {
+		if area.visited[i] {
+			continue
+		}
+
+		start := Point{X: area.origin.X + i%area.width, Y: area.origin.Y + i/area.width}
+		points := floodFill(grid, area, start, conn, same)
+
+		label := len(regions)
+		for _, p := range points {
+			labels.cells[area.index(p)] = label
+		}
+
+		cell, _ := grid.At(start.X, start.Y)
+		regions = append(regions, Region[TCell]{
+			Label: label,
+			Cell:  cell,
+			Start: start,
+		})
+	}
+
+	measureRegions(labels, area.origin, regions)
+
+	return labels, regions
+}
+
+// measureRegions fills in the size and shape of every labelled region in one pass over the labels.
+// Label (0, 0) is origin in the labelled grid's coordinates.
+func measureRegions[TCell any](labels *Grid[int], origin Point, regions []Region[TCell]) {
+	for i := range regions {
+		regions[i].Min = regions[i].Start
+		regions[i].Max = regions[i].Start
+	}
+
+	for p, label := range labels.All() {
+		region := &regions[label]
+		inRegion := func(x, y int) bool {
+			other, err := labels.At(x, y)
+			return err == nil && other == label
+		}
+
+		region.Area++
+		at := Point{X: origin.X + p.X, Y: origin.Y + p.Y}
+		region.Min = Point{X: min(region.Min.X, at.X), Y: min(region.Min.Y, at.Y)}
+		region.Max = Point{X: max(region.Max.X, at.X), Y: max(region.Max.Y, at.Y)}
+
+		for _, d := range orthogonalDirections {
+			outX, outY := d.ApplyDelta(p.X, p.Y)
+			if inRegion(outX, outY) {
+				continue
+			}
+			region.Perimeter++
+
+			// A fence only starts a new side when the cell before it along the fence has no fence there.
+			alongX, alongY := d.RotateCCW().RotateCCW().ApplyDelta(p.X, p.Y)
+			beyondX, beyondY := d.ApplyDelta(alongX, alongY)
+			if !inRegion(alongX, alongY) || inRegion(beyondX, beyondY) {
+				region.Sides++
+			}
+		}
+
+		// Each diagonal quadrant is a convex corner when both edges are open,
+		// and a concave one when both edges are closed but the diagonal is open.
+		for _, diagonal := range []Direction{NE, SE, SW, NW} {
+			firstX, firstY := diagonal.RotateCCW().ApplyDelta(p.X, p.Y)
+			secondX, secondY := diagonal.RotateCW().ApplyDelta(p.X, p.Y)
+			diagX, diagY := diagonal.ApplyDelta(p.X, p.Y)
+
+			first, second := inRegion(firstX, firstY), inRegion(secondX, secondY)
+			if (!first && !second) || (first && second && !inRegion(diagX, diagY)) {
+				region.Corners++
+			}
+		}
+	}
+}
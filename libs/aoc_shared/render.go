@@ -0,0 +1,212 @@
+package aocshared
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+)
+
+// CellStyle is how one cell is drawn. A nil colour keeps the terminal default.
+// In overlays, a zero Glyph or nil colour leaves that part of the cell underneath unchanged.
+type CellStyle struct {
+	Glyph rune
+	FG    color.Color
+	BG    color.Color
+}
+
+// RenderOverlay restyles the points it reports; later overlays are drawn over earlier ones.
+type RenderOverlay func(p Point) (CellStyle, bool)
+
+// OverlayPoints highlights a fixed set of points, such as a path found by a search.
+func OverlayPoints(points []Point, style CellStyle) RenderOverlay {
+	set := make(map[Point]bool, len(points))
+	for _, p := range points {
+		set[p] = true
+	}
+
+	return func(p Point) (CellStyle, bool) {
+		return style, set[p]
+	}
+}
+
+// OverlayWhere highlights the cells of a grid that match, such as the marker of placed shapes.
+func OverlayWhere[TCell any](grid CellGrid[TCell], match func(cell TCell) bool, style CellStyle) RenderOverlay {
+	return func(p Point) (CellStyle, bool) {
+		cell, err := grid.At(p.X, p.Y)
+		return style, err == nil && match(cell)
+	}
+}
+
+// GridRenderer draws grids to a terminal with colours and overlays.
+type GridRenderer[TCell any] struct {
+	style    func(cell TCell) CellStyle
+	overlays []RenderOverlay
+
+	color bool
+
+	viewportSet    bool
+	viewportOrigin Point
+	viewportWidth  int
+	viewportHeight int
+}
+
+// GridRendererCreate makes a renderer for stdout: colour is on when stdout is a terminal and NO_COLOR is not set.
+func GridRendererCreate[TCell any](style func(cell TCell) CellStyle) *GridRenderer[TCell] {
+	return &GridRenderer[TCell]{
+		style: style,
+		color: ColorEnabled(os.Stdout),
+	}
+}
+
+// ColorEnabled reports whether ANSI colours should be written to the file, following https://no-color.org.
+func ColorEnabled(file *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	fd := file.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+func GridRendererSetColor[TCell any](renderer *GridRenderer[TCell], enabled bool) {
+	renderer.color = enabled
+}
+
+func GridRendererAddOverlay[TCell any](renderer *GridRenderer[TCell], overlay RenderOverlay) {
+	renderer.overlays = append(renderer.overlays, overlay)
+}
+
+// GridRendererSetViewport limits drawing to width x height cells starting at origin.
+func GridRendererSetViewport[TCell any](renderer *GridRenderer[TCell], origin Point, width, height int) {
+	renderer.viewportSet = true
+	renderer.viewportOrigin = origin
+	renderer.viewportWidth = width
+	renderer.viewportHeight = height
+}
+
+// GridRendererCenterOn moves the viewport so p sits in its middle, keeping its size.
+func GridRendererCenterOn[TCell any](renderer *GridRenderer[TCell], p Point) {
+	renderer.viewportOrigin = Point{X: p.X - renderer.viewportWidth/2, Y: p.Y - renderer.viewportHeight/2}
+}
+
+// GridRender writes the grid, cropped to the viewport, one line per row.
+func GridRender[TCell any](w io.Writer, renderer *GridRenderer[TCell], grid CellGrid[TCell]) error {
+	minPoint, maxPoint := grid.Bounds()
+	if renderer.viewportSet {
+		minPoint = Point{X: max(minPoint.X, renderer.viewportOrigin.X), Y: max(minPoint.Y, renderer.viewportOrigin.Y)}
+		maxPoint = Point{
+			X: min(maxPoint.X, renderer.viewportOrigin.X+renderer.viewportWidth-1),
+			Y: min(maxPoint.Y, renderer.viewportOrigin.Y+renderer.viewportHeight-1),
+		}
+	}
+
+	var sb strings.Builder
+	for y := minPoint.Y; y <= maxPoint.Y; y++ {
+		var current CellStyle
+		for x := minPoint.X; x <= maxPoint.X; x++ {
+			style := renderer.cellStyle(grid, Point{X: x, Y: y})
+
+			if renderer.color && (!sameColor(style.FG, current.FG) || !sameColor(style.BG, current.BG)) {
+				sb.WriteString(ansiStyle(style))
+				current = style
+			}
+			sb.WriteRune(style.Glyph)
+		}
+
+		if renderer.color {
+			sb.WriteString(ansiReset)
+		}
+		sb.WriteByte('\n')
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// rendererReservedRows are the terminal rows GridRendererPrint leaves to the cropping note and the prompt after it.
+const rendererReservedRows = 2
+
+// GridRendererPrint draws to stdout. Without an explicit viewport, grids larger than the terminal are cropped to it.
+func GridRendererPrint[TCell any](renderer *GridRenderer[TCell], grid CellGrid[TCell]) error {
+	if !renderer.viewportSet {
+		if width, height, ok := terminalSize(os.Stdout); ok {
+			minPoint, maxPoint := grid.Bounds()
+			gridWidth, gridHeight := maxPoint.X-minPoint.X+1, maxPoint.Y-minPoint.Y+1
+
+			// Even the tiniest terminal gets one row and column of the grid.
+			rows, cols := max(height-rendererReservedRows, 1), max(width, 1)
+			if gridWidth > cols || gridHeight > rows {
+				cropped := *renderer
+				GridRendererSetViewport(&cropped, minPoint, cols, rows)
+				fmt.Printf("(showing %dx%d of %dx%d cells)\n", min(cols, gridWidth), min(rows, gridHeight), gridWidth, gridHeight)
+				return GridRender(os.Stdout, &cropped, grid)
+			}
+		}
+	}
+
+	return GridRender(os.Stdout, renderer, grid)
+}
+
+func (renderer *GridRenderer[TCell]) cellStyle(grid CellGrid[TCell], p Point) CellStyle {
+	cell, _ := grid.At(p.X, p.Y)
+	style := renderer.style(cell)
+
+	for _, overlay := range renderer.overlays {
+		over, ok := overlay(p)
+		if !ok {
+			continue
+		}
+		if over.Glyph != 0 {
+			style.Glyph = over.Glyph
+		}
+		if over.FG != nil {
+			style.FG = over.FG
+		}
+		if over.BG != nil {
+			style.BG = over.BG
+		}
+	}
+
+	if style.Glyph == 0 {
+		style.Glyph = ' '
+	}
+	return style
+}
+
+const ansiReset = "\x1b[0m"
+
+// ansiStyle sets both colours from scratch using 24-bit colour codes.
+func ansiStyle(style CellStyle) string {
+	var sb strings.Builder
+	sb.WriteString(ansiReset)
+
+	if style.FG != nil {
+		r, g, b := rgb8(style.FG)
+		fmt.Fprintf(&sb, "\x1b[38;2;%d;%d;%dm", r, g, b)
+	}
+	if style.BG != nil {
+		r, g, b := rgb8(style.BG)
+		fmt.Fprintf(&sb, "\x1b[48;2;%d;%d;%dm", r, g, b)
+	}
+
+	return sb.String()
+}
+
+func rgb8(c color.Color) (r, g, b uint8) {
+	r16, g16, b16, _ := c.RGBA()
+	return uint8(r16 >> 8), uint8(g16 >> 8), uint8(b16 >> 8)
+}
+
+func sameColor(a, b color.Color) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	return ar == br && ag == bg && ab == bb && aa == ba
+}
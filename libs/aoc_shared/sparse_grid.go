@@ -0,0 +1,161 @@
+package aocshared
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// SparseGrid is an unbounded grid that only stores the cells that were set.
+// Coordinates may be negative; unset cells read as the fill value.
+type SparseGrid[TCell any] struct {
+	cells map[Point]TCell
+	fill  TCell
+
+	// The bounding box is kept up to date by Set and Delete, so reads never write to the grid.
+	minPoint Point
+	maxPoint Point
+}
+
+var _ CellGrid[int] = (*Grid[int])(nil)
+var _ CellGrid[int] = (*SparseGrid[int])(nil)
+
+func SparseGridCreate[TCell any](fill TCell) *SparseGrid[TCell] {
+	return &SparseGrid[TCell]{
+		cells: make(map[Point]TCell),
+		fill:  fill,
+	}
+}
+
+func SparseGridClone[TCell any](grid *SparseGrid[TCell]) *SparseGrid[TCell] {
+	cloned := *grid
+	cloned.cells = maps.Clone(grid.cells)
+	return &cloned
+}
+
+func (grid *SparseGrid[TCell]) Len() int {
+	return len(grid.cells)
+}
+
+// At never fails: cells that were never set hold the fill value.
+func (grid *SparseGrid[TCell]) At(x, y int) (TCell, error) {
+	if cell, ok := grid.cells[Point{X: x, Y: y}]; ok {
+		return cell, nil
+	}
+	return grid.fill, nil
+}
+
+func (grid *SparseGrid[TCell]) Has(x, y int) bool {
+	_, ok := grid.cells[Point{X: x, Y: y}]
+	return ok
+}
+
+func (grid *SparseGrid[TCell]) Set(x, y int, cell TCell) error {
+	p := Point{X: x, Y: y}
+
+	if len(grid.cells) == 0 {
+		grid.minPoint, grid.maxPoint = p, p
+	} else {
+		grid.minPoint = Point{X: min(grid.minPoint.X, x), Y: min(grid.minPoint.Y, y)}
+		grid.maxPoint = Point{X: max(grid.maxPoint.X, x), Y: max(grid.maxPoint.Y, y)}
+	}
+
+	grid.cells[p] = cell
+	return nil
+}
+
+func (grid *SparseGrid[TCell]) Delete(x, y int) {
+	if !grid.Has(x, y) {
+		return
+	}
+
+	delete(grid.cells, Point{X: x, Y: y})
+	if x == grid.minPoint.X || x == grid.maxPoint.X || y == grid.minPoint.Y || y == grid.maxPoint.Y {
+		grid.recomputeBounds()
+	}
+}
+
+// recomputeBounds rebuilds the bounding box from the stored cells after a deletion on its edge.
+func (grid *SparseGrid[TCell]) recomputeBounds() {
+	first := true
+	for p := range grid.cells {
+		if first {
+			grid.minPoint, grid.maxPoint = p, p
+			first = false
+			continue
+		}
+		grid.minPoint = Point{X: min(grid.minPoint.X, p.X), Y: min(grid.minPoint.Y, p.Y)}
+		grid.maxPoint = Point{X: max(grid.maxPoint.X, p.X), Y: max(grid.maxPoint.Y, p.Y)}
+	}
+}
+
+// InBounds reports whether the point lies inside the bounding box of the stored cells.
+func (grid *SparseGrid[TCell]) InBounds(x, y int) bool {
+	if len(grid.cells) == 0 {
+		return false
+	}
+
+	minPoint, maxPoint := grid.Bounds()
+	return x >= minPoint.X && y >= minPoint.Y && x <= maxPoint.X && y <= maxPoint.Y
+}
+
+// Bounds returns the corners of the bounding box of the stored cells. An empty grid has maxPoint < minPoint.
+func (grid *SparseGrid[TCell]) Bounds() (minPoint, maxPoint Point) {
+	if len(grid.cells) == 0 {
+		return Point{}, Point{X: -1, Y: -1}
+	}
+
+	return grid.minPoint, grid.maxPoint
+}
+
+// All yields the stored cells in reading order.
+func (grid *SparseGrid[TCell]) All() iter.Seq2[Point, TCell] {
+	return func(yield func(Point, TCell) bool) {
+		for _, p := range sortedPoints(grid.cells) {
+			if !yield(p, grid.cells[p]) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors yields every neighbour of p, stored or not, since the grid has no edge.
+func (grid *SparseGrid[TCell]) Neighbors(p Point, conn Connectivity) iter.Seq[Point] {
+	directions := orthogonalDirections
+	if conn == Conn8 {
+		directions = allDirections
+	}
+
+	return func(yield func(Point) bool) {
+		for _, d := range directions {
+			x, y := d.ApplyDelta(p.X, p.Y)
+			if !yield(Point{X: x, Y: y}) {
+				return
+			}
+		}
+	}
+}
+
+// Adjacencies returns the eight surrounding cells indexed by Direction, unset ones being the fill value.
+func (grid *SparseGrid[TCell]) Adjacencies(x, y int) [8]TCell {
+	return cellAdjacencies(grid, x, y)
+}
+
+// ForEach visits the stored cells in reading order.
+func (grid *SparseGrid[TCell]) ForEach(exec func(cell TCell, x, y int)) {
+	for p, cell := range grid.All() {
+		exec(cell, p.X, p.Y)
+	}
+}
+
+// Debug prints the bounding box of the stored cells, showing unset cells as the fill value.
+func (grid *SparseGrid[TCell]) Debug(cellFormatter func(cell TCell) string) {
+	debugCells(grid, cellFormatter)
+}
+
+func sortedPoints[TCell any](cells map[Point]TCell) []Point {
+	return slices.SortedFunc(maps.Keys(cells), func(a, b Point) int {
+		return cmp.Or(cmp.Compare(a.Y, b.Y), cmp.Compare(a.X, b.X))
+	})
+}
@@ -0,0 +1,148 @@
+package aocshared
+
+import (
+	"iter"
+	"maps"
+	"sync"
+)
+
+// GridVector is what a SparseGridN needs from its keys; Vec2, Vec3 and VecN all qualify.
+type GridVector[V any] interface {
+	comparable
+	Dims() int
+	Add(other V) V
+	Min(other V) V
+	Max(other V) V
+	Offsets(conn Connectivity) []V
+}
+
+// SparseGridN is SparseGrid for any number of dimensions, keyed by vectors such as Vec3[int] or VecN[int].
+// Unset cells read as the fill value.
+type SparseGridN[V GridVector[V], TCell any] struct {
+	cells map[V]TCell
+	fill  TCell
+
+	offsets sync.Map // vectorOffsetsKey to []V: neighbour steps, worked out on first use
+}
+
+// vectorOffsetsKey tells neighbourhoods apart by dimension too, since VecN keys pick theirs at run time.
+type vectorOffsetsKey struct {
+	conn Connectivity
+	dims int
+}
+
+func SparseGridNCreate[V GridVector[V], TCell any](fill TCell) *SparseGridN[V, TCell] {
+	return &SparseGridN[V, TCell]{
+		cells: make(map[V]TCell),
+		fill:  fill,
+	}
+}
+
+func SparseGridNClone[V GridVector[V], TCell any](grid *SparseGridN[V, TCell]) *SparseGridN[V, TCell] {
+	cloned := &SparseGridN[V, TCell]{
+		cells: maps.Clone(grid.cells),
+		fill:  grid.fill,
+	}
+	grid.offsets.Range(func(key, offsets any) bool {
+		cloned.offsets.Store(key, offsets)
+		return true
+	})
+	return cloned
+}
+
+func (grid *SparseGridN[V, TCell]) Len() int {
+	return len(grid.cells)
+}
+
+func (grid *SparseGridN[V, TCell]) At(v V) TCell {
+	if cell, ok := grid.cells[v]; ok {
+		return cell
+	}
+	return grid.fill
+}
+
+func (grid *SparseGridN[V, TCell]) Has(v V) bool {
+	_, ok := grid.cells[v]
+	return ok
+}
+
+func (grid *SparseGridN[V, TCell]) Set(v V, cell TCell) {
+	grid.cells[v] = cell
+}
+
+func (grid *SparseGridN[V, TCell]) Delete(v V) {
+	delete(grid.cells, v)
+}
+
+// All yields the set cells in no particular order.
+func (grid *SparseGridN[V, TCell]) All() iter.Seq2[V, TCell] {
+	return maps.All(grid.cells)
+}
+
+// Bounds returns the corners of the box around the set cells; ok is false when there are none.
+func (grid *SparseGridN[V, TCell]) Bounds() (minVec, maxVec V, ok bool) {
+	for v := range grid.cells {
+		if !ok {
+			minVec, maxVec, ok = v, v, true
+			continue
+		}
+		minVec, maxVec = minVec.Min(v), maxVec.Max(v)
+	}
+	return minVec, maxVec, ok
+}
+
+// Neighbors yields the 2n or 3^n-1 cells around v, set or not. Like the other reads it is safe to
+// call from several goroutines while nothing writes to the grid.
+func (grid *SparseGridN[V, TCell]) Neighbors(v V, conn Connectivity) iter.Seq[V] {
+	key := vectorOffsetsKey{conn: conn, dims: v.Dims()}
+	cached, ok := grid.offsets.Load(key)
+	if !ok {
+		cached, _ = grid.offsets.LoadOrStore(key, v.Offsets(conn))
+	}
+	offsets := cached.([]V)
+
+	return func(yield func(V) bool) {
+		for _, offset := range offsets {
+			if !yield(v.Add(offset)) {
+				return
+			}
+		}
+	}
+}
+
+// CountNeighbors counts the cells around v whose value matches, unset ones reading as the fill value.
+func (grid *SparseGridN[V, TCell]) CountNeighbors(v V, conn Connectivity, match func(cell TCell) bool) int {
+	count := 0
+	for neighbor := range grid.Neighbors(v, conn) {
+		if match(grid.At(neighbor)) {
+			count++
+		}
+	}
+	return count
+}
+
+// Frontier yields every set cell and every cell next to one, each once: the only cells whose
+// neighbourhood holds anything but the fill value, and so the ones to visit in an automaton step.
+func (grid *SparseGridN[V, TCell]) Frontier(conn Connectivity) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		seen := make(map[V]bool, len(grid.cells))
+		visit := func(v V) bool {
+			if seen[v] {
+				return true
+			}
+			seen[v] = true
+			return yield(v)
+		}
+
+		for v := range grid.cells {
+			if !visit(v) {
+				return
+			}
+			for neighbor := range grid.Neighbors(v, conn) {
+				if !visit(neighbor) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package aocshared
+
+import "os"
+
+// terminalSize is unknown off unix, so grids are never cropped automatically there.
+func terminalSize(file *os.File) (width, height int, ok bool) {
+	return 0, 0, false
+}
@@ -0,0 +1,19 @@
+//go:build unix
+
+package aocshared
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// terminalSize returns the size of the terminal behind file in cells, when it is one.
+func terminalSize(file *os.File) (width, height int, ok bool) {
+	size, err := unix.IoctlGetWinsize(int(file.Fd()), unix.TIOCGWINSZ)
+	if err != nil || size.Col == 0 || size.Row == 0 {
+		return 0, 0, false
+	}
+
+	return int(size.Col), int(size.Row), true
+}
@@ -0,0 +1,147 @@
+package aocshared
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// TextBlock is text padded with spaces into a rectangle, for puzzles that lay data out in columns.
+type TextBlock struct {
+	rows [][]rune
+
+	width  int
+	height int
+}
+
+// TextBlockCreate pads every line of the input to the longest one. Trailing blank lines are dropped.
+func TextBlockCreate(input string) *TextBlock {
+	lines := strings.Split(strings.ReplaceAll(input, "\r\n", "\n"), "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return TextBlockFromLines(lines)
+}
+
+// TextBlockFromLines pads the given lines to the longest one.
+func TextBlockFromLines(lines []string) *TextBlock {
+	width := 0
+	for _, line := range lines {
+		width = max(width, utf8.RuneCountInString(line))
+	}
+
+	rows := make([][]rune, len(lines))
+	for y, line := range lines {
+		rows[y] = []rune(line + strings.Repeat(" ", width-utf8.RuneCountInString(line)))
+	}
+
+	return &TextBlock{rows: rows, width: width, height: len(lines)}
+}
+
+func TextBlockSize(block *TextBlock) (width, height int) {
+	return block.width, block.height
+}
+
+func TextBlockAt(block *TextBlock, x, y int) (rune, error) {
+	if x < 0 || y < 0 || x >= block.width || y >= block.height {
+		return 0, fmt.Errorf("invalid coords")
+	}
+
+	return block.rows[y][x], nil
+}
+
+// TextBlockRow reads row y left to right, padding included.
+func TextBlockRow(block *TextBlock, y int) (string, error) {
+	if y < 0 || y >= block.height {
+		return "", fmt.Errorf("invalid row %d", y)
+	}
+
+	return string(block.rows[y]), nil
+}
+
+// TextBlockCol reads column x top to bottom, padding included.
+func TextBlockCol(block *TextBlock, x int) (string, error) {
+	if x < 0 || x >= block.width {
+		return "", fmt.Errorf("invalid column %d", x)
+	}
+
+	col := make([]rune, block.height)
+	for y := range block.rows {
+		col[y] = block.rows[y][x]
+	}
+	return string(col), nil
+}
+
+// TextBlockRows returns rows [from, to) as a new block.
+func TextBlockRows(block *TextBlock, from, to int) (*TextBlock, error) {
+	if from < 0 || to > block.height || from > to {
+		return nil, fmt.Errorf("invalid row range [%d, %d)", from, to)
+	}
+
+	rows := make([][]rune, to-from)
+	for i := range rows {
+		rows[i] = append([]rune(nil), block.rows[from+i]...)
+	}
+
+	return &TextBlock{rows: rows, width: block.width, height: len(rows)}, nil
+}
+
+// TextBlockCols returns columns [from, to) as a new block.
+func TextBlockCols(block *TextBlock, from, to int) (*TextBlock, error) {
+	if from < 0 || to > block.width || from > to {
+		return nil, fmt.Errorf("invalid column range [%d, %d)", from, to)
+	}
+
+	rows := make([][]rune, block.height)
+	for y := range rows {
+		rows[y] = append([]rune(nil), block.rows[y][from:to]...)
+	}
+
+	return &TextBlock{rows: rows, width: to - from, height: block.height}, nil
+}
+
+// TextBlockIsBlankCol reports whether column x holds only spaces; columns outside the block are not blank.
+func TextBlockIsBlankCol(block *TextBlock, x int) bool {
+	if x < 0 || x >= block.width {
+		return false
+	}
+
+	for y := range block.rows {
+		if block.rows[y][x] != ' ' {
+			return false
+		}
+	}
+	return true
+}
+
+// TextBlockPanels splits the block into the vertical panels between all-space columns, left to right.
+// Runs of blank columns count as one separator, and blank columns at either edge are dropped.
+func TextBlockPanels(block *TextBlock) []*TextBlock {
+	panels := make([]*TextBlock, 0)
+
+	start := -1
+	for x := 0; x <= block.width; x++ {
+		blank := x == block.width || TextBlockIsBlankCol(block, x)
+
+		if !blank && start < 0 {
+			start = x
+		}
+
+		if blank && start >= 0 {
+			panel, _ := TextBlockCols(block, start, x) // always within the block
+			panels = append(panels, panel)
+			start = -1
+		}
+	}
+
+	return panels
+}
+
+func TextBlockString(block *TextBlock) string {
+	lines := make([]string, block.height)
+	for y := range block.rows {
+		lines[y] = string(block.rows[y])
+	}
+	return strings.Join(lines, "\n")
+}
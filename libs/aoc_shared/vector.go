@@ -0,0 +1,336 @@
+package aocshared
+
+import (
+	"fmt"
+	"math"
+)
+
+// Number is any signed integer or float type a vector can be built from.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~float32 | ~float64
+}
+
+// Vector neighbourhoods reuse Connectivity; these names read better outside two dimensions.
+const (
+	ConnOrthogonal = Conn4 // one axis changes by one: 4 in 2D, 6 in 3D, 2n in nD
+	ConnAll        = Conn8 // any axes change by one: 8 in 2D, 26 in 3D, 3^n-1 in nD
+)
+
+func absNumber[T Number](n T) T {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+type Vec2[T Number] struct {
+	X, Y T
+}
+
+func (v Vec2[T]) Dims() int {
+	return 2
+}
+
+func (v Vec2[T]) Add(other Vec2[T]) Vec2[T] {
+	return Vec2[T]{X: v.X + other.X, Y: v.Y + other.Y}
+}
+
+func (v Vec2[T]) Sub(other Vec2[T]) Vec2[T] {
+	return Vec2[T]{X: v.X - other.X, Y: v.Y - other.Y}
+}
+
+func (v Vec2[T]) Scale(factor T) Vec2[T] {
+	return Vec2[T]{X: v.X * factor, Y: v.Y * factor}
+}
+
+func (v Vec2[T]) Abs() Vec2[T] {
+	return Vec2[T]{X: absNumber(v.X), Y: absNumber(v.Y)}
+}
+
+func (v Vec2[T]) Dot(other Vec2[T]) T {
+	return v.X*other.X + v.Y*other.Y
+}
+
+// Cross is the z component of the 3D cross product: positive when other is counter-clockwise from v
+// with y pointing up, clockwise on screen with y pointing down.
+func (v Vec2[T]) Cross(other Vec2[T]) T {
+	return v.X*other.Y - v.Y*other.X
+}
+
+func (v Vec2[T]) Min(other Vec2[T]) Vec2[T] {
+	return Vec2[T]{X: min(v.X, other.X), Y: min(v.Y, other.Y)}
+}
+
+func (v Vec2[T]) Max(other Vec2[T]) Vec2[T] {
+	return Vec2[T]{X: max(v.X, other.X), Y: max(v.Y, other.Y)}
+}
+
+func (v Vec2[T]) Manhattan(other Vec2[T]) T {
+	d := v.Sub(other).Abs()
+	return d.X + d.Y
+}
+
+func (v Vec2[T]) Chebyshev(other Vec2[T]) T {
+	d := v.Sub(other).Abs()
+	return max(d.X, d.Y)
+}
+
+// EuclideanSquared avoids the square root, and stays exact for integers.
+func (v Vec2[T]) EuclideanSquared(other Vec2[T]) T {
+	d := v.Sub(other)
+	return d.Dot(d)
+}
+
+func (v Vec2[T]) Euclidean(other Vec2[T]) float64 {
+	d := v.Sub(other)
+	dx, dy := float64(d.X), float64(d.Y)
+	return math.Sqrt(dx*dx + dy*dy)
+}
+
+// Offsets lists the steps to v's neighbours, so Vec2 works as a SparseGridN key.
+func (v Vec2[T]) Offsets(conn Connectivity) []Vec2[T] {
+	offsets := make([]Vec2[T], 0, 8)
+	for _, d := range neighborDirections(conn) {
+		offsets = append(offsets, Vec2[T]{X: T(DirDelta[d].DX), Y: T(DirDelta[d].DY)})
+	}
+	return offsets
+}
+
+func neighborDirections(conn Connectivity) []Direction {
+	if conn == Conn8 {
+		return allDirections
+	}
+	return orthogonalDirections
+}
+
+type Vec3[T Number] struct {
+	X, Y, Z T
+}
+
+func (v Vec3[T]) Dims() int {
+	return 3
+}
+
+func (v Vec3[T]) Add(other Vec3[T]) Vec3[T] {
+	return Vec3[T]{X: v.X + other.X, Y: v.Y + other.Y, Z: v.Z + other.Z}
+}
+
+func (v Vec3[T]) Sub(other Vec3[T]) Vec3[T] {
+	return Vec3[T]{X: v.X - other.X, Y: v.Y - other.Y, Z: v.Z - other.Z}
+}
+
+func (v Vec3[T]) Scale(factor T) Vec3[T] {
+	return Vec3[T]{X: v.X * factor, Y: v.Y * factor, Z: v.Z * factor}
+}
+
+func (v Vec3[T]) Abs() Vec3[T] {
+	return Vec3[T]{X: absNumber(v.X), Y: absNumber(v.Y), Z: absNumber(v.Z)}
+}
+
+func (v Vec3[T]) Dot(other Vec3[T]) T {
+	return v.X*other.X + v.Y*other.Y + v.Z*other.Z
+}
+
+func (v Vec3[T]) Cross(other Vec3[T]) Vec3[T] {
+	return Vec3[T]{
+		X: v.Y*other.Z - v.Z*other.Y,
+		Y: v.Z*other.X - v.X*other.Z,
+		Z: v.X*other.Y - v.Y*other.X,
+	}
+}
+
+func (v Vec3[T]) Min(other Vec3[T]) Vec3[T] {
+	return Vec3[T]{X: min(v.X, other.X), Y: min(v.Y, other.Y), Z: min(v.Z, other.Z)}
+}
+
+func (v Vec3[T]) Max(other Vec3[T]) Vec3[T] {
+	return Vec3[T]{X: max(v.X, other.X), Y: max(v.Y, other.Y), Z: max(v.Z, other.Z)}
+}
+
+func (v Vec3[T]) Manhattan(other Vec3[T]) T {
+	d := v.Sub(other).Abs()
+	return d.X + d.Y + d.Z
+}
+
+func (v Vec3[T]) Chebyshev(other Vec3[T]) T {
+	d := v.Sub(other).Abs()
+	return max(d.X, d.Y, d.Z)
+}
+
+// EuclideanSquared avoids the square root, and stays exact for integers.
+func (v Vec3[T]) EuclideanSquared(other Vec3[T]) T {
+	d := v.Sub(other)
+	return d.Dot(d)
+}
+
+func (v Vec3[T]) Euclidean(other Vec3[T]) float64 {
+	d := v.Sub(other)
+	dx, dy, dz := float64(d.X), float64(d.Y), float64(d.Z)
+	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+}
+
+// Offsets lists the steps to v's 6 or 26 neighbours, so Vec3 works as a SparseGridN key.
+func (v Vec3[T]) Offsets(conn Connectivity) []Vec3[T] {
+	offsets := make([]Vec3[T], 0, 26)
+	for _, step := range unitSteps(3, conn) {
+		offsets = append(offsets, Vec3[T]{X: T(step[0]), Y: T(step[1]), Z: T(step[2])})
+	}
+	return offsets
+}
+
+// VecMaxDims bounds the number of components of a VecN, which keeps VecN comparable and usable as a map key.
+const VecMaxDims = 8
+
+// VecN is a vector with a number of components chosen at run time. Operations on two vectors
+// expect them to have the same number of components.
+type VecN[T Number] struct {
+	dims       int
+	components [VecMaxDims]T
+}
+
+// VecNOf builds a vector from its components; it panics past VecMaxDims of them.
+func VecNOf[T Number](components ...T) VecN[T] {
+	if len(components) > VecMaxDims {
+		panic(fmt.Sprintf("vector has %d components, at most %d are supported", len(components), VecMaxDims))
+	}
+
+	v := VecN[T]{dims: len(components)}
+	copy(v.components[:], components)
+	return v
+}
+
+// VecNZero is the origin in dims dimensions.
+func VecNZero[T Number](dims int) VecN[T] {
+	return VecNOf(make([]T, dims)...)
+}
+
+func (v VecN[T]) Dims() int {
+	return v.dims
+}
+
+func (v VecN[T]) At(i int) T {
+	return v.Components()[i]
+}
+
+func (v VecN[T]) Components() []T {
+	return v.components[:v.dims]
+}
+
+func (v VecN[T]) String() string {
+	return fmt.Sprint(v.Components())
+}
+
+// zip combines the components of two vectors pairwise.
+func (v VecN[T]) zip(other VecN[T], combine func(a, b T) T) VecN[T] {
+	result := VecN[T]{dims: v.dims}
+	for i := range v.dims {
+		result.components[i] = combine(v.components[i], other.components[i])
+	}
+	return result
+}
+
+func (v VecN[T]) Add(other VecN[T]) VecN[T] {
+	return v.zip(other, func(a, b T) T { return a + b })
+}
+
+func (v VecN[T]) Sub(other VecN[T]) VecN[T] {
+	return v.zip(other, func(a, b T) T { return a - b })
+}
+
+func (v VecN[T]) Scale(factor T) VecN[T] {
+	return v.zip(v, func(a, _ T) T { return a * factor })
+}
+
+func (v VecN[T]) Abs() VecN[T] {
+	return v.zip(v, func(a, _ T) T { return absNumber(a) })
+}
+
+func (v VecN[T]) Min(other VecN[T]) VecN[T] {
+	return v.zip(other, func(a, b T) T { return min(a, b) })
+}
+
+func (v VecN[T]) Max(other VecN[T]) VecN[T] {
+	return v.zip(other, func(a, b T) T { return max(a, b) })
+}
+
+func (v VecN[T]) Dot(other VecN[T]) T {
+	var sum T
+	for i := range v.dims {
+		sum += v.components[i] * other.components[i]
+	}
+	return sum
+}
+
+func (v VecN[T]) Manhattan(other VecN[T]) T {
+	var sum T
+	for _, c := range v.Sub(other).Abs().Components() {
+		sum += c
+	}
+	return sum
+}
+
+func (v VecN[T]) Chebyshev(other VecN[T]) T {
+	var largest T
+	for _, c := range v.Sub(other).Abs().Components() {
+		largest = max(largest, c)
+	}
+	return largest
+}
+
+// EuclideanSquared avoids the square root, and stays exact for integers.
+func (v VecN[T]) EuclideanSquared(other VecN[T]) T {
+	d := v.Sub(other)
+	return d.Dot(d)
+}
+
+func (v VecN[T]) Euclidean(other VecN[T]) float64 {
+	sum := 0.0
+	for _, c := range v.Sub(other).Components() {
+		sum += float64(c) * float64(c)
+	}
+	return math.Sqrt(sum)
+}
+
+// Offsets lists the steps to v's neighbours in its own number of dimensions, so VecN works as a SparseGridN key.
+func (v VecN[T]) Offsets(conn Connectivity) []VecN[T] {
+	steps := unitSteps(v.dims, conn)
+	offsets := make([]VecN[T], len(steps))
+	for i, step := range steps {
+		offsets[i].dims = v.dims
+		for axis, delta := range step {
+			offsets[i].components[axis] = T(delta)
+		}
+	}
+	return offsets
+}
+
+// unitSteps lists every non-zero step of -1, 0 or 1 per axis, or with ConnOrthogonal those along a single axis.
+func unitSteps(dims int, conn Connectivity) [][]int {
+	steps := make([][]int, 0)
+
+	if conn == ConnOrthogonal {
+		for axis := range dims {
+			for _, delta := range []int{-1, 1} {
+				step := make([]int, dims)
+				step[axis] = delta
+				steps = append(steps, step)
+			}
+		}
+		return steps
+	}
+
+	// Count through base 3, reading each digit as -1, 0 or 1.
+	total := int(math.Pow(3, float64(dims)))
+	for n := range total {
+		step := make([]int, dims)
+		zero := true
+		for axis, rest := 0, n; axis < dims; axis, rest = axis+1, rest/3 {
+			step[axis] = rest%3 - 1
+			zero = zero && step[axis] == 0
+		}
+		if !zero {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
@@ -0,0 +1,72 @@
+package aocshared
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// XXH64 primes, from the xxHash specification. They are variables so the seed set-up below can
+// wrap around like the specification's unsigned arithmetic, which constant expressions refuse to do.
+var (
+	xxhPrime1 uint64 = 11400714785074694791
+	xxhPrime2 uint64 = 14029467366897019727
+	xxhPrime3 uint64 = 1609587929392839161
+	xxhPrime4 uint64 = 9650029242287828579
+	xxhPrime5 uint64 = 2870177450012600261
+)
+
+// xxh64 is the XXH64 hash of data with a zero seed, as defined by the xxHash specification.
+func xxh64(data []byte) uint64 {
+	n := uint64(len(data))
+
+	var h uint64
+	if len(data) >= 32 {
+		v1, v2, v3, v4 := xxhPrime1+xxhPrime2, xxhPrime2, uint64(0), -xxhPrime1
+		for ; len(data) >= 32; data = data[32:] {
+			v1 = xxhRound(v1, binary.LittleEndian.Uint64(data[0:8]))
+			v2 = xxhRound(v2, binary.LittleEndian.Uint64(data[8:16]))
+			v3 = xxhRound(v3, binary.LittleEndian.Uint64(data[16:24]))
+			v4 = xxhRound(v4, binary.LittleEndian.Uint64(data[24:32]))
+		}
+
+		h = bits.RotateLeft64(v1, 1) + bits.RotateLeft64(v2, 7) + bits.RotateLeft64(v3, 12) + bits.RotateLeft64(v4, 18)
+		h = xxhMergeRound(h, v1)
+		h = xxhMergeRound(h, v2)
+		h = xxhMergeRound(h, v3)
+		h = xxhMergeRound(h, v4)
+	} else {
+		h = xxhPrime5
+	}
+	h += n
+
+	for ; len(data) >= 8; data = data[8:] {
+		h ^= xxhRound(0, binary.LittleEndian.Uint64(data))
+		h = bits.RotateLeft64(h, 27)*xxhPrime1 + xxhPrime4
+	}
+	if len(data) >= 4 {
+		h ^= uint64(binary.LittleEndian.Uint32(data)) * xxhPrime1
+		h = bits.RotateLeft64(h, 23)*xxhPrime2 + xxhPrime3
+		data = data[4:]
+	}
+	for _, b := range data {
+		h ^= uint64(b) * xxhPrime5
+		h = bits.RotateLeft64(h, 11) * xxhPrime1
+	}
+
+	h ^= h >> 33
+	h *= xxhPrime2
+	h ^= h >> 29
+	h *= xxhPrime3
+	h ^= h >> 32
+	return h
+}
+
+func xxhRound(acc, lane uint64) uint64 {
+	acc += lane * xxhPrime2
+	return bits.RotateLeft64(acc, 31) * xxhPrime1
+}
+
+func xxhMergeRound(acc, val uint64) uint64 {
+	acc ^= xxhRound(0, val)
+	return acc*xxhPrime1 + xxhPrime4
+}
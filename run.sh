@@ -77,10 +77,10 @@ fi
 
 # 3. Handle --today logic
 if [ "$TODAY_MODE" = true ]; then
-    # Get current year and day. 
+    # Get current year and day in US Eastern time, where puzzles unlock at midnight.
     # ${d#0} strips leading zeros so "05" becomes "5", preventing octal confusion.
-    CURRENT_YEAR=$(date +%Y)
-    d=$(date +%d)
+    CURRENT_YEAR=$(TZ=America/New_York date +%Y)
+    d=$(TZ=America/New_York date +%d)
     CURRENT_DAY=${d#0}
     
     # Overwrite args to force today's date
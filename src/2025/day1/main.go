@@ -4,7 +4,6 @@ import (
 	aocshared "aoc_shared"
 	"fmt"
 	"strconv"
-	"strings"
 )
 
 func floorDiv(n, d int) int {
@@ -18,10 +17,7 @@ func wrapAround(maxNum, current, diff int) (int, int) {
 	rangeSize := maxNum + 1
 	raw := current + diff
 
-	remainder := raw % rangeSize
-	if remainder < 0 {
-		remainder += rangeSize
-	}
+	remainder := aocshared.WrapIndex(raw, rangeSize)
 
 	var zeros int
 	if diff > 0 {
@@ -36,20 +32,23 @@ func wrapAround(maxNum, current, diff int) (int, int) {
 }
 
 func main() {
-	input := aocshared.GetInput(2025, 1)
-	rotations := strings.Split(input, "\n")
+	input, err := aocshared.GetInput(2025, 1)
+	if err != nil {
+		panic(err)
+	}
+
+	rotations := aocshared.ParseLines(input)
 	fmt.Printf("Number of rotations: %d\n", len(rotations))
 
 	current := 50
 	zeroCounter1 := 0
 	zeroCounter2 := 0
 	for _, rotation := range rotations {
-		if len(rotation) == 0 {
-			continue
-		}
-
 		direction := string(rotation[0])
-		amount, _ := strconv.Atoi(string(rotation[1:]))
+		amount, err := strconv.Atoi(rotation[1:])
+		if err != nil {
+			panic(fmt.Errorf("invalid rotation %q: %w", rotation, err))
+		}
 
 		if direction == "L" {
 			amount = -amount
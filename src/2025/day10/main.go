@@ -4,9 +4,6 @@ import (
 	aocshared "aoc_shared"
 	"fmt"
 	"math/big"
-	"regexp"
-	"strconv"
-	"strings"
 )
 
 const test = false
@@ -37,59 +34,30 @@ type Day10 struct {
 
 type Machine struct {
 	IndicatorLightDiagram string
-	ButtonWiring          []string
-	JoltageRequirements   string
+	ButtonWiring          [][]int
+	JoltageRequirements   []int
 }
 
 func (d *Day10) GatherInput() {
+	var err error
+
 	if test {
-		d.input = aocshared.GetTestInput(2025, 10)
+		d.input, err = aocshared.GetTestInput(2025, 10)
 	} else {
-		d.input = aocshared.GetInput(2025, 10)
+		d.input, err = aocshared.GetInput(2025, 10)
 	}
-}
-
-var MacroRegex = regexp.MustCompile(`^\[([.#]+)\]\s+((?:\(\d+(?:,\d+)*\)\s*)+)\s+\{(\d+(?:,\d+)*)\}$`)
-var MicroRegex = regexp.MustCompile(`\(([^)]+)\)`)
 
-func extractMajorComponents(line string) (indicator string, schematicsRaw string, joltageRaw string, err error) {
-	matches := MacroRegex.FindStringSubmatch(line)
-	if len(matches) != 4 {
-		return "", "", "", fmt.Errorf("line does not match expected format: %s", line)
+	if err != nil {
+		panic(err)
 	}
-	return matches[1], matches[2], matches[3], nil
 }
 
-func tokenizeSchematics(rawString string) []string {
-	matches := MicroRegex.FindAllStringSubmatch(rawString, -1)
-	var tokens []string
-	for _, match := range matches {
-		if len(match) > 1 {
-			tokens = append(tokens, match[1])
-		}
-	}
-	return tokens
-}
+const machinePattern = `\[{IndicatorLightDiagram}\] {ButtonWiring:(...)+} \{{JoltageRequirements:ints}\}`
 
 func (d *Day10) ParseInput() {
-	lines := strings.Split(strings.TrimSpace(d.input), "\n")
-	machines := make([]Machine, 0, len(lines))
-
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-		indicator, schematicsRaw, joltageRaw, err := extractMajorComponents(line)
-		if err != nil {
-			fmt.Println("Error parsing line:", line, err)
-			continue
-		}
-		schematicsList := tokenizeSchematics(schematicsRaw)
-		machines = append(machines, Machine{
-			IndicatorLightDiagram: indicator,
-			ButtonWiring:          schematicsList,
-			JoltageRequirements:   joltageRaw,
-		})
+	machines := make([]Machine, 0)
+	if err := aocshared.Unmarshal(aocshared.ParseLines(d.input), &machines, machinePattern); err != nil {
+		panic(err)
 	}
 	d.machines = machines
 }
@@ -112,13 +80,7 @@ func solveMachine(machine Machine) int {
 	buttonsParsed := make([]bitset, len(machine.ButtonWiring))
 
 	for i, wiring := range machine.ButtonWiring {
-		numbers := strings.Split(wiring, ",")
-		parsed := make([]int, len(numbers))
-		for j, number := range numbers {
-			v, _ := strconv.Atoi(number)
-			parsed[j] = v
-		}
-		buttonsParsed[i] = parseButton(parsed)
+		buttonsParsed[i] = parseButton(wiring)
 	}
 
 	matrix := buildMatrix(machine, buttonsParsed)
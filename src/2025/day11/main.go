@@ -33,14 +33,20 @@ type day11 struct {
 }
 
 func (d *day11) GetInput() {
+	var err error
+
 	if test {
 		if test2 {
-			d.input = aocshared.GetTestInputN(2025, 11, 2)
+			d.input, err = aocshared.GetTestInputN(2025, 11, 2)
 		} else {
-			d.input = aocshared.GetTestInput(2025, 11)
+			d.input, err = aocshared.GetTestInput(2025, 11)
 		}
 	} else {
-		d.input = aocshared.GetInput(2025, 11)
+		d.input, err = aocshared.GetInput(2025, 11)
+	}
+
+	if err != nil {
+		panic(err)
 	}
 }
 
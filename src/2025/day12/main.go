@@ -43,47 +43,39 @@ type Region struct {
 }
 
 func (d *day12) GetInput() {
+	var err error
+
 	if test {
-		d.input = aocshared.GetTestInput(2025, 12)
+		d.input, err = aocshared.GetTestInput(2025, 12)
 	} else {
-		d.input = aocshared.GetInput(2025, 12)
+		d.input, err = aocshared.GetInput(2025, 12)
+	}
+
+	if err != nil {
+		panic(err)
 	}
 }
 
-// Parser written by Gemini, as parsing is not what this problem is about.
+const regionPattern = `{Width}x{Height}: {PresentCounts}`
 
 func (d *day12) ParseInput() {
-	lines := strings.Split(d.input, "\n")
 	d.shapes = make([]Shape, 0)
 	d.regions = make([]Region, 0)
 
-	shapeParsing := true
-
-	for i := 0; i < len(lines); i++ {
-		line := strings.TrimSpace(lines[i])
-
-		if line == "" {
-			continue
-		}
-
-		if shapeParsing && strings.HasSuffix(lines[i], ":") {
-			if i+3 >= len(lines) {
-				panic(fmt.Errorf("malformed shape definition starting at line %d", i+1))
+	blocks, startLines := aocshared.ParseBlocksNumbered(d.input)
+	for i, block := range blocks {
+		if strings.HasSuffix(strings.TrimSpace(block[0]), ":") {
+			if len(block) != 4 {
+				panic(fmt.Errorf("line %d: malformed shape definition %q: expected 3 rows, got %d", startLines[i], block[0], len(block)-1))
 			}
 
-			s := d.parseShapeBlock(lines[i], lines[i+1], lines[i+2], lines[i+3])
-			d.shapes = append(d.shapes, s)
-			i += 3
+			d.shapes = append(d.shapes, d.parseShapeBlock(strings.TrimSpace(block[0]), block[1], block[2], block[3]))
 			continue
 		}
 
-		shapeParsing = false
-
-		region, err := d.parseRegionLine(line)
-		if err != nil {
-			panic(fmt.Errorf("unhandled line format (after shapes) at line %d: %s, error: %w", i+1, line, err))
+		if err := aocshared.Unmarshal(block, &d.regions, regionPattern); err != nil {
+			panic(fmt.Errorf("unhandled region block: %w", aocshared.ParseErrorInBlock(err, startLines[i])))
 		}
-		d.regions = append(d.regions, region)
 	}
 
 	fmt.Printf("Parsed %d shapes and %d regions.\n", len(d.shapes), len(d.regions))
@@ -118,39 +110,6 @@ func (d *day12) parseShapeBlock(idxLine, r1, r2, r3 string) Shape {
 	return s
 }
 
-func (d *day12) parseRegionLine(line string) (Region, error) {
-	parts := strings.SplitN(line, ": ", 2)
-	if len(parts) != 2 {
-		return Region{}, fmt.Errorf("region line missing dimensions or counts: %s", line)
-	}
-
-	dimParts := strings.Split(parts[0], "x")
-	if len(dimParts) != 2 {
-		return Region{}, fmt.Errorf("region dimensions malformed (expected WxH): %s", parts[0])
-	}
-	width, errW := strconv.Atoi(dimParts[0])
-	height, errH := strconv.Atoi(dimParts[1])
-	if errW != nil || errH != nil {
-		return Region{}, fmt.Errorf("invalid region dimension integers: %s", parts[0])
-	}
-
-	countStrs := strings.Fields(parts[1])
-	counts := make([]int, len(countStrs))
-	for i, s := range countStrs {
-		count, err := strconv.Atoi(s)
-		if err != nil {
-			return Region{}, fmt.Errorf("invalid present count integer: %s", s)
-		}
-		counts[i] = count
-	}
-
-	return Region{
-		Width:         width,
-		Height:        height,
-		PresentCounts: counts,
-	}, nil
-}
-
 func (d *day12) SolvePart1AreaCheck() {
 	numValid := 0
 
@@ -202,23 +161,18 @@ func solveRegion(region Region, shapes []Shape) bool {
 		return false
 	}
 
-	grid := createEmptyGrid(region.Width, region.Height)
+	grid := aocshared.BitGridCreate(region.Width, region.Height)
 
 	flags := aocshared.PlacementFlags{
 		AllowRotate: true,
 		AllowFlip:   true,
 	}
 
-	return aocshared.GridCanFitShapes(
-		grid,
-		shapeMap,
-		shapeCounts,
-		flags,
-		func(cell bool, x, y int) bool {
-			return !cell
-		},
-		true,
-	)
+	fits, err := aocshared.BitGridCanFitShapes(grid, shapeMap, shapeCounts, flags)
+	if err != nil {
+		panic(err)
+	}
+	return fits
 }
 
 func checkRegionAreaOnly(region Region, shapes []Shape) bool {
@@ -257,17 +211,6 @@ func convertShapeToOffsets(shape Shape) []aocshared.ShapeOffset {
 	return offsets
 }
 
-func createEmptyGrid(width, height int) *aocshared.Grid[bool] {
-	rows := make([][]bool, height)
-	for y := 0; y < height; y++ {
-		rows[y] = make([]bool, width)
-		for x := 0; x < width; x++ {
-			rows[y][x] = false
-		}
-	}
-	return aocshared.GridCreate(rows)
-}
-
 func (d *day12) PrintResults() {
 	fmt.Printf("Number of Valid Regions: %d\n", d.validRegions)
 }
@@ -64,7 +64,11 @@ func isSequencePrimitive(sequence, sequenceLength int) bool {
 // ------------------ MAIN EXECUTION ------------------
 
 func main() {
-	input := aocshared.GetInput(2025, 2)
+	input, err := aocshared.GetInput(2025, 2)
+	if err != nil {
+		panic(err)
+	}
+
 	ranges := strings.Split(input, ",")
 
 	sumInvalidIDsP1 := 0
@@ -11,7 +11,11 @@ func main() {
 }
 
 func run() {
-	input := aocshared.GetTestInput(2025, 3)
+	input, err := aocshared.GetTestInput(2025, 3)
+	if err != nil {
+		panic(err)
+	}
+
 	banks := strings.Split(input, "\n")
 
 	joltSum := 0
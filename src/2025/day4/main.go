@@ -3,76 +3,63 @@ package main
 import (
 	aocshared "aoc_shared"
 	"fmt"
-	"strings"
+	"image/color"
+	"path/filepath"
 )
 
+// recordGIF saves every removal round to build/day4.gif.
+const recordGIF = false
+
 func main() {
 	aocshared.DebugAndLogTask("2025 day 4", solve)
 }
 
 func solve() {
-	input := aocshared.GetInput(2025, 3)
-	rows := strings.Split(input, "\n")
-	convertedRows := make([][]bool, 0)
-
-	for _, row := range rows {
-		trimmed := strings.TrimSpace(row)
-		if trimmed == "" {
-			continue
-		}
-
-		cells := make([]bool, len(row))
-		for x, char := range row {
-			if char == '.' {
-				cells[x] = false
-			} else {
-				cells[x] = true
-			}
-		}
+	input, err := aocshared.GetInput(2025, 4)
+	if err != nil {
+		panic(err)
+	}
 
-		convertedRows = append(convertedRows, cells)
+	grid, _, err := aocshared.GridParse(input, map[rune]bool{'.': false, '@': true})
+	if err != nil {
+		panic(err)
 	}
 
-	rollsOfPaperCanLift := 0
-	rollsOfPaperCanLiftOverTime := 0
+	automaton := aocshared.AutomatonCreate(grid, liftRule)
+	aocshared.AutomatonTrackActive(automaton)
+
+	var recorder *aocshared.GridRecorder[bool]
+	if recordGIF {
+		recorder = aocshared.GridRecorderCreate(2, 10, color.Palette{color.Black, color.White}, rollColor)
+		aocshared.GridRecorderAddFrame(recorder, aocshared.AutomatonGrid(automaton))
+	}
 
-	grid := aocshared.GridCreate(convertedRows)
-	rollsOfPaperCanLift = solveIteration(grid)
-	rollsOfPaperCanLiftOverTime = rollsOfPaperCanLift
-	aocshared.GridQueuedOpsApply(grid)
+	rollsOfPaperCanLift := aocshared.AutomatonStep(automaton)
+	rollsOfPaperCanLiftOverTime := rollsOfPaperCanLift
 
 	for {
-		if canMove := solveIteration(grid); canMove == 0 {
+		if recorder != nil {
+			aocshared.GridRecorderAddFrame(recorder, aocshared.AutomatonGrid(automaton))
+		}
+
+		canMove := aocshared.AutomatonStep(automaton)
+		if canMove == 0 {
 			break
-		} else {
-			rollsOfPaperCanLiftOverTime += canMove
-			aocshared.GridQueuedOpsApply(grid)
 		}
+		rollsOfPaperCanLiftOverTime += canMove
+	}
+
+	if recorder != nil {
+		saveRecording(recorder)
 	}
 
 	fmt.Printf("Can Lift Rolls (P1): %d\n", rollsOfPaperCanLift)
 	fmt.Printf("Can Lift Rolls (P2): %d\n", rollsOfPaperCanLiftOverTime)
 }
 
-func solveIteration(grid *aocshared.Grid[bool]) int {
-	rollsOfPaperCanLift := 0
-
-	aocshared.GridForEach(*grid, func(cell bool, x, y int) {
-		if !cell {
-			return
-		}
-
-		adjacencies := aocshared.GridGetAdjacencies(*grid, x, y)
-		if canLift(adjacencies) {
-			rollsOfPaperCanLift++
-			aocshared.GridQueueOp(grid, func() {
-				aocshared.GridSetAt(grid, x, y, false)
-			})
-			// aocshared.GridSetAt(grid, x, y, false)
-		}
-	})
-
-	return rollsOfPaperCanLift
+// liftRule removes every roll that can be lifted; empty spaces stay empty.
+func liftRule(roll bool, adjacencies [8]bool, x, y int) bool {
+	return roll && !canLift(adjacencies)
 }
 
 func canLift(s [8]bool) bool {
@@ -90,3 +77,23 @@ func canLift(s [8]bool) bool {
 
 	return true
 }
+
+func rollColor(roll bool) color.Color {
+	if roll {
+		return color.White
+	}
+	return color.Black
+}
+
+func saveRecording(recorder *aocshared.GridRecorder[bool]) {
+	root, err := aocshared.RepoRoot()
+	if err != nil {
+		panic(err)
+	}
+
+	path := filepath.Join(root, "build", "day4.gif")
+	if err := aocshared.GridRecorderSaveGIF(path, recorder); err != nil {
+		panic(err)
+	}
+	fmt.Printf("Saved %d frames to %s\n", aocshared.GridRecorderFrames(recorder), path)
+}
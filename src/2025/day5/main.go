@@ -4,7 +4,6 @@ import (
 	aocshared "aoc_shared"
 	"fmt"
 	"slices"
-	"strconv"
 	"strings"
 )
 
@@ -13,19 +12,24 @@ func main() {
 }
 
 func solve() {
-	input := aocshared.GetInput(2025, 5)
-	lines := strings.Split(input, "\n")
-
-	ranges := make([]ingredientRange, 0)
-	ingredients := make([]int, 0)
-	for _, line := range lines {
-		if strings.Contains(line, "-") {
-			buildRange(line, &ranges)
-		} else {
-			if ingredient, err := strconv.Atoi(line); err == nil {
-				ingredients = append(ingredients, ingredient)
-			}
-		}
+	input, err := aocshared.GetInput(2025, 5)
+	if err != nil {
+		panic(err)
+	}
+
+	blocks, startLines := aocshared.ParseBlocksNumbered(input)
+	if len(blocks) != 2 {
+		panic(fmt.Errorf("expected a range block and an ingredient block, got %d blocks", len(blocks)))
+	}
+
+	ranges, err := buildRanges(blocks[0], startLines[0])
+	if err != nil {
+		panic(fmt.Errorf("invalid range block: %w", err))
+	}
+
+	ingredients, err := buildIngredients(blocks[1], startLines[1])
+	if err != nil {
+		panic(fmt.Errorf("invalid ingredient block: %w", err))
 	}
 
 	slices.SortStableFunc(ranges, func(a, b ingredientRange) int {
@@ -111,15 +115,41 @@ func minimizeRanges(sortedRangeSlice []ingredientRange) []ingredientRange {
 	return minimized
 }
 
-func buildRange(line string, rangeSlice *[]ingredientRange) {
-	parts := strings.Split(line, "-")
-	part1Cnv, _ := strconv.Atoi(parts[0])
-	part2Cnv, _ := strconv.Atoi(parts[1])
+// buildRanges reads the range block, which starts on line firstLine of the input.
+func buildRanges(lines []string, firstLine int) ([]ingredientRange, error) {
+	parsed, err := aocshared.ParseRanges(strings.Join(lines, "\n"))
+	if err != nil {
+		return nil, aocshared.ParseErrorInBlock(err, firstLine)
+	}
 
-	*rangeSlice = append(*rangeSlice, ingredientRange{
-		start: part1Cnv,
-		end:   part2Cnv,
-	})
+	ranges := make([]ingredientRange, len(parsed))
+	for i, r := range parsed {
+		ranges[i] = ingredientRange{
+			start: r.Start,
+			end:   r.End,
+		}
+	}
+
+	return ranges, nil
+}
+
+// buildIngredients reads the ingredient IDs, one per line, from the block starting on line firstLine.
+func buildIngredients(lines []string, firstLine int) ([]int, error) {
+	parsed, err := aocshared.ParseIntLines(strings.Join(lines, "\n"))
+	if err != nil {
+		return nil, aocshared.ParseErrorInBlock(err, firstLine)
+	}
+
+	ingredients := make([]int, len(parsed))
+	for i, values := range parsed {
+		// Block lines are never blank, so the i-th parsed line is the i-th line of the block.
+		if len(values) != 1 {
+			return nil, &aocshared.ParseError{Line: firstLine + i, Column: 1, Msg: fmt.Sprintf("expected exactly one ingredient ID in %q, got %d", strings.TrimSpace(lines[i]), len(values))}
+		}
+		ingredients[i] = values[0]
+	}
+
+	return ingredients, nil
 }
 
 // FindNearestIndex finds the index of the element closest to the target.
@@ -22,119 +22,64 @@ func AddOp(current, next int) int {
 }
 
 func solve() {
-	input := aocshared.GetInput(2025, 6)
-	original := strings.Split(input, "\n")
-	lines := original[:len(original)-1]
-
-	rawOperators := strings.Fields(original[len(original)-1])
-
-	operators := []Operator{}
-
-	for _, operator := range rawOperators {
-		if operator == "*" {
-			operators = append(operators, MultiplyOp)
-		}
-		if operator == "+" {
-			operators = append(operators, AddOp)
-		}
-	}
-
-	numProblems := len(operators)
-
-	part1Problems := make([][][]rune, numProblems)
-	part2Problems := make([][][]rune, numProblems) // problemNum -> numberPos -> digits
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if trimmed == "" {
-			continue
-		}
-
-		// Pt1
-		numbersInLine := strings.Fields(trimmed)
-
-		for problemNum, number := range numbersInLine {
-			if part1Problems[problemNum] == nil {
-				part1Problems[problemNum] = make([][]rune, 0)
-			}
-			part1Problems[problemNum] = append(part1Problems[problemNum], []rune(number))
-		}
-
-		// Pt2
-		currentProblemNum := 0
-
-		numberStartColumns := make([]int, 0)
-		isWhitespace := true
-
-		for i, char := range line {
-			if char != ' ' && isWhitespace {
-				numberStartColumns = append(numberStartColumns, i)
-				isWhitespace = false
-			} else if char == ' ' {
-				isWhitespace = true
-			}
-		}
-
-		for colIndex, char := range line {
-			currentProblemNum = 0
-			for i := 1; i < len(numberStartColumns); i++ {
-				if colIndex >= numberStartColumns[i] {
-					currentProblemNum = i
-				}
-			}
-
-			if currentProblemNum < len(part2Problems) {
-				if part2Problems[currentProblemNum] == nil {
-					part2Problems[currentProblemNum] = make([][]rune, 0)
-				}
-
-				for len(part2Problems[currentProblemNum]) <= colIndex {
-					part2Problems[currentProblemNum] = append(part2Problems[currentProblemNum], make([]rune, 0))
-				}
-
-				if char >= '0' && char <= '9' {
-					part2Problems[currentProblemNum][colIndex] = append(part2Problems[currentProblemNum][colIndex], char)
-				}
-			}
-		}
+	input, err := aocshared.GetInput(2025, 6)
+	if err != nil {
+		panic(err)
 	}
 
 	grandTotal := 0
 	grandTotal2 := 0
 
-	for problemNum, problem := range part1Problems {
-		numbers := make([]int, len(problem))
+	// Every problem is a panel of columns between all-space columns, with its operator on the last row.
+	for _, problem := range aocshared.TextBlockPanels(aocshared.TextBlockCreate(input)) {
+		width, height := aocshared.TextBlockSize(problem)
+		operator := parseOperator(strings.TrimSpace(must(aocshared.TextBlockRow(problem, height-1))))
+		numbers := must(aocshared.TextBlockRows(problem, 0, height-1))
 
-		for i, number := range problem {
-			numberString := string(number)
-			v, _ := strconv.Atoi(numberString)
-			numbers[i] = v
+		// Pt1: one number per row
+		rowNumbers := make([]int, 0, height-1)
+		for y := 0; y < height-1; y++ {
+			rowNumbers = append(rowNumbers, parseNumber(must(aocshared.TextBlockRow(numbers, y))))
 		}
+		grandTotal += solveColumn(operator, rowNumbers)
 
-		operator := operators[problemNum]
-		grandTotal += solveColumn(operator, numbers)
+		// Pt2: one number per column, read top to bottom
+		colNumbers := make([]int, 0, width)
+		for x := 0; x < width; x++ {
+			colNumbers = append(colNumbers, parseNumber(must(aocshared.TextBlockCol(numbers, x))))
+		}
+		grandTotal2 += solveColumn(operator, colNumbers)
 	}
 
-	for problemNum, problem := range part2Problems {
-		finalNumbers := make([]int, 0)
-
-		for _, digitRunes := range problem {
-			numberString := string(digitRunes)
-
-			if numberString == "" {
-				continue
-			}
+	fmt.Printf("Total (P1): %d\n", grandTotal)
+	fmt.Printf("Total (P2): %d\n", grandTotal2)
+}
 
-			v, _ := strconv.Atoi(numberString)
-			finalNumbers = append(finalNumbers, v)
-		}
+// must unwraps a TextBlock read, panicking like the rest of solve on malformed input.
+func must[T any](value T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
 
-		operator := operators[problemNum]
-		grandTotal2 += solveColumn(operator, finalNumbers)
+func parseOperator(raw string) Operator {
+	switch raw {
+	case "*":
+		return MultiplyOp
+	case "+":
+		return AddOp
+	default:
+		panic(fmt.Errorf("unknown operator: '%s'", raw))
 	}
+}
 
-	fmt.Printf("Total (P1): %d\n", grandTotal)
-	fmt.Printf("Total (P2): %d\n", grandTotal2)
+func parseNumber(raw string) int {
+	v, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil {
+		panic(err)
+	}
+	return v
 }
 
 func solveColumn(problemFn Operator, values []int) int {
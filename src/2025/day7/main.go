@@ -3,10 +3,14 @@ package main
 import (
 	aocshared "aoc_shared"
 	"fmt"
+	"image/color"
+	"path/filepath"
 	"slices"
-	"strings"
 )
 
+// exportPNG saves the beam field after part 1 to build/day7.png.
+const exportPNG = false
+
 type CellType int
 
 const (
@@ -15,44 +19,32 @@ const (
 	BEAM
 )
 
-type Vector2 struct {
-	X int
-	Y int
-}
-
 func main() {
 	aocshared.DebugAndLogTask("2025 Day 7", solve)
 }
 
 func solve() {
-	input := aocshared.GetInput(2025, 7)
-	lines := strings.Split(input, "\n")
-
-	rows := make([][]CellType, len(lines))
-	cellsPerRow := len(lines[0])
-
-	initialBeamPosition := Vector2{X: -1, Y: -1}
-	for y, line := range lines {
-		cellsInRow := make([]CellType, cellsPerRow)
-
-		for x, char := range line {
-			cellType := getCellType(char)
-
-			if cellType == BEAM {
-				initialBeamPosition.X = x
-				initialBeamPosition.Y = y
-			}
+	input, err := aocshared.GetInput(2025, 7)
+	if err != nil {
+		panic(err)
+	}
 
-			cellsInRow[x] = cellType
-		}
+	grid, markers, err := aocshared.GridParseFunc(input, getCellType, 'S')
+	if err != nil {
+		panic(err)
+	}
 
-		rows[y] = cellsInRow
+	if len(markers['S']) != 1 {
+		panic(fmt.Errorf("expected exactly one start, found %d", len(markers['S'])))
 	}
+	initialBeamPosition := aocshared.Vec2[int]{X: markers['S'][0].X, Y: markers['S'][0].Y}
 
-	grid := aocshared.GridCreate(rows)
 	part2Grid := aocshared.GridClone(grid)
 
 	splitTimes := getSplitTimes(grid, initialBeamPosition)
+	if exportPNG {
+		saveBeamField(grid)
+	}
 	activeTimelines := getActiveTimelines(part2Grid, initialBeamPosition)
 
 	fmt.Printf("Split Times (P1): %d\n", splitTimes)
@@ -60,24 +52,51 @@ func solve() {
 }
 
 func debugGrid(grid *aocshared.Grid[CellType]) {
-	aocshared.GridDebug(*grid, func(cell CellType) string {
+	renderer := aocshared.GridRendererCreate(func(cell CellType) aocshared.CellStyle {
 		switch cell {
 		case SPACE:
-			return "."
+			return aocshared.CellStyle{Glyph: '.', FG: color.Gray{Y: 90}}
 		case SPLITTER:
-			return "^"
+			return aocshared.CellStyle{Glyph: '^', FG: color.RGBA{R: 200, G: 60, B: 60, A: 255}}
 		case BEAM:
-			return "|"
+			return aocshared.CellStyle{Glyph: '|', FG: color.RGBA{R: 250, G: 220, B: 80, A: 255}}
 		default:
 			panic(fmt.Errorf("unknown character: '%v'", cell))
 		}
 	})
+
+	if err := aocshared.GridRendererPrint(renderer, grid); err != nil {
+		panic(err)
+	}
+}
+
+func saveBeamField(grid *aocshared.Grid[CellType]) {
+	root, err := aocshared.RepoRoot()
+	if err != nil {
+		panic(err)
+	}
+
+	path := filepath.Join(root, "build", "day7.png")
+	err = aocshared.GridSavePNG(path, grid, 4, func(cell CellType) color.Color {
+		switch cell {
+		case SPLITTER:
+			return color.RGBA{R: 200, G: 60, B: 60, A: 255}
+		case BEAM:
+			return color.RGBA{R: 250, G: 220, B: 80, A: 255}
+		default:
+			return color.Black
+		}
+	})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("Saved beam field to %s\n", path)
 }
 
-func getSplitTimes(grid *aocshared.Grid[CellType], initialPosition Vector2) int {
+func getSplitTimes(grid *aocshared.Grid[CellType], initialPosition aocshared.Vec2[int]) int {
 	splitTimes := 0
 
-	currentBeamPositionsToBeProcessed := []Vector2{initialPosition}
+	currentBeamPositionsToBeProcessed := []aocshared.Vec2[int]{initialPosition}
 
 	for i := 0; len(currentBeamPositionsToBeProcessed) != 0; i++ {
 		// fmt.Printf("Iteration: %d (START) - grid:\n", i)
@@ -87,7 +106,7 @@ func getSplitTimes(grid *aocshared.Grid[CellType], initialPosition Vector2) int
 		beamsInQueue := len(currentBeamPositionsToBeProcessed)
 		for _, beamInQueue := range currentBeamPositionsToBeProcessed {
 			southX, southY := aocshared.S.ApplyDelta(beamInQueue.X, beamInQueue.Y)
-			cell, err := aocshared.GridGetAt(*grid, southX, southY)
+			cell, err := grid.At(southX, southY)
 
 			if err != nil {
 				continue
@@ -95,21 +114,21 @@ func getSplitTimes(grid *aocshared.Grid[CellType], initialPosition Vector2) int
 
 			switch cell {
 			case SPACE:
-				currentBeamPositionsToBeProcessed = append(currentBeamPositionsToBeProcessed, Vector2{X: southX, Y: southY})
-				aocshared.GridSetAt(grid, southX, southY, BEAM)
+				currentBeamPositionsToBeProcessed = append(currentBeamPositionsToBeProcessed, aocshared.Vec2[int]{X: southX, Y: southY})
+				grid.Set(southX, southY, BEAM)
 			case SPLITTER:
 				splitTimes += 1
 				eastX, eastY := aocshared.E.ApplyDelta(southX, southY)
 				westX, westY := aocshared.W.ApplyDelta(southX, southY)
 
-				if cellType, err := aocshared.GridGetAt(*grid, eastX, eastY); cellType == SPACE && err == nil {
-					currentBeamPositionsToBeProcessed = append(currentBeamPositionsToBeProcessed, Vector2{X: eastX, Y: eastY})
-					aocshared.GridSetAt(grid, eastX, eastY, BEAM)
+				if cellType, err := grid.At(eastX, eastY); cellType == SPACE && err == nil {
+					currentBeamPositionsToBeProcessed = append(currentBeamPositionsToBeProcessed, aocshared.Vec2[int]{X: eastX, Y: eastY})
+					grid.Set(eastX, eastY, BEAM)
 				}
 
-				if cellType, err := aocshared.GridGetAt(*grid, westX, westY); cellType == SPACE && err == nil {
-					currentBeamPositionsToBeProcessed = append(currentBeamPositionsToBeProcessed, Vector2{X: westX, Y: westY})
-					aocshared.GridSetAt(grid, westX, westY, BEAM)
+				if cellType, err := grid.At(westX, westY); cellType == SPACE && err == nil {
+					currentBeamPositionsToBeProcessed = append(currentBeamPositionsToBeProcessed, aocshared.Vec2[int]{X: westX, Y: westY})
+					grid.Set(westX, westY, BEAM)
 				}
 			case BEAM:
 
@@ -124,12 +143,12 @@ func getSplitTimes(grid *aocshared.Grid[CellType], initialPosition Vector2) int
 	return splitTimes
 }
 
-func getActiveTimelines(grid *aocshared.Grid[CellType], initialPosition Vector2) int {
-	cache := map[Vector2]int{}
+func getActiveTimelines(grid *aocshared.Grid[CellType], initialPosition aocshared.Vec2[int]) int {
+	cache := map[aocshared.Vec2[int]]int{}
 	return solvePosition(grid, cache, initialPosition)
 }
 
-func solvePosition(grid *aocshared.Grid[CellType], cache map[Vector2]int, position Vector2) int {
+func solvePosition(grid *aocshared.Grid[CellType], cache map[aocshared.Vec2[int]]int, position aocshared.Vec2[int]) int {
 	if cached, ok := cache[position]; ok {
 		return cached
 	}
@@ -137,17 +156,17 @@ func solvePosition(grid *aocshared.Grid[CellType], cache map[Vector2]int, positi
 	result := 0
 
 	southX, southY := aocshared.S.ApplyDelta(position.X, position.Y)
-	if cell, err := aocshared.GridGetAt(*grid, southX, southY); err != nil {
+	if cell, err := grid.At(southX, southY); err != nil {
 		result += 1
 	} else {
 		switch cell {
 		case SPACE:
-			result += solvePosition(grid, cache, Vector2{X: southX, Y: southY})
+			result += solvePosition(grid, cache, aocshared.Vec2[int]{X: southX, Y: southY})
 		case SPLITTER:
 			eastX, eastY := aocshared.E.ApplyDelta(southX, southY)
 			westX, westY := aocshared.W.ApplyDelta(southX, southY)
-			result += solvePosition(grid, cache, Vector2{X: eastX, Y: eastY})
-			result += solvePosition(grid, cache, Vector2{X: westX, Y: westY})
+			result += solvePosition(grid, cache, aocshared.Vec2[int]{X: eastX, Y: eastY})
+			result += solvePosition(grid, cache, aocshared.Vec2[int]{X: westX, Y: westY})
 		}
 	}
 
@@ -157,15 +176,15 @@ func solvePosition(grid *aocshared.Grid[CellType], cache map[Vector2]int, positi
 }
 
 //go:inline
-func getCellType(char rune) CellType {
+func getCellType(char rune) (CellType, error) {
 	switch char {
 	case '.':
-		return SPACE
+		return SPACE, nil
 	case '^':
-		return SPLITTER
+		return SPLITTER, nil
 	case 'S', '|':
-		return BEAM
+		return BEAM, nil
 	default:
-		panic(fmt.Errorf("unknown character: '%s'", string(char)))
+		return SPACE, fmt.Errorf("expected one of '.', '^', 'S' or '|'")
 	}
 }
@@ -3,10 +3,7 @@ package main
 import (
 	aocshared "aoc_shared"
 	"fmt"
-	"math"
 	"slices"
-	"strconv"
-	"strings"
 )
 
 const test = false
@@ -28,7 +25,7 @@ func main() {
 type Day8 struct {
 	pairsToConsider int
 	input           string
-	boxes           []Vector3
+	boxes           []aocshared.Vec3[int]
 	edges           []Edge
 	circuits        []*Circuit
 
@@ -57,44 +54,31 @@ func (c Circuit) Size() int {
 	return len(c.members)
 }
 
-type Vector3 struct {
-	x, y, z int
-}
-
-func (v Vector3) EuclidianDistance(other Vector3) float64 {
-	return math.Sqrt(v.EuclidianDistanceSquared(other))
-}
-
-func (v Vector3) EuclidianDistanceSquared(other Vector3) float64 {
-	dx := float64(v.x - other.x)
-	dy := float64(v.y - other.y)
-	dz := float64(v.z - other.z)
-
-	return dx*dx + dy*dy + dz*dz
-}
-
 func (d *Day8) GatherInput() {
+	var err error
+
 	if test {
-		d.input = aocshared.GetTestInput(2025, 8)
+		d.input, err = aocshared.GetTestInput(2025, 8)
 		d.pairsToConsider = 10
 	} else {
-		d.input = aocshared.GetInput(2025, 8)
+		d.input, err = aocshared.GetInput(2025, 8)
 		d.pairsToConsider = 1000
 	}
+
+	if err != nil {
+		panic(err)
+	}
 }
 
 func (d *Day8) ParseInput() {
-	lines := strings.Split(d.input, "\n")
-
-	boxes := make([]Vector3, len(lines))
-	for i, line := range lines {
-		coords := strings.Split(line, ",")
-
-		x, _ := strconv.Atoi(coords[0])
-		y, _ := strconv.Atoi(coords[1])
-		z, _ := strconv.Atoi(coords[2])
+	tuples, err := aocshared.ParseTuples(d.input, 3)
+	if err != nil {
+		panic(err)
+	}
 
-		boxes[i] = Vector3{x: x, y: y, z: z}
+	boxes := make([]aocshared.Vec3[int], len(tuples))
+	for i, tuple := range tuples {
+		boxes[i] = aocshared.Vec3[int]{X: tuple[0], Y: tuple[1], Z: tuple[2]}
 	}
 
 	d.boxes = boxes
@@ -108,7 +92,7 @@ func (d *Day8) BuildEdges() {
 			edges = append(edges, Edge{
 				idxA:     i,
 				idxB:     j,
-				distance: d.boxes[i].EuclidianDistance(d.boxes[j]),
+				distance: d.boxes[i].Euclidean(d.boxes[j]),
 			})
 		}
 	}
@@ -190,7 +174,7 @@ func (d *Day8) ConnectBoxes() {
 			finalA := d.boxes[edge.idxA]
 			finalB := d.boxes[edge.idxB]
 
-			d.part2Result = finalA.x * finalB.x
+			d.part2Result = finalA.X * finalB.X
 			break
 		}
 	}
@@ -3,10 +3,7 @@ package main
 import (
 	aocshared "aoc_shared"
 	"fmt"
-	"math"
 	"slices"
-	"strconv"
-	"strings"
 )
 
 const test = false
@@ -32,12 +29,12 @@ type CompressedSpace struct {
 	satGrid [][]int
 }
 
-func buildCompressedSpace(coords []Vector2, boundaryLookup map[Vector2]bool) CompressedSpace {
+func buildCompressedSpace(coords []aocshared.Vec2[int], boundaryLookup map[aocshared.Vec2[int]]bool) CompressedSpace {
 	uniqueX := make(map[int]struct{})
 	uniqueY := make(map[int]struct{})
 	for _, coord := range coords {
-		uniqueX[coord.x] = struct{}{}
-		uniqueY[coord.y] = struct{}{}
+		uniqueX[coord.X] = struct{}{}
+		uniqueY[coord.Y] = struct{}{}
 	}
 
 	sortedX := make([]int, 0, len(uniqueX))
@@ -71,7 +68,7 @@ func buildCompressedSpace(coords []Vector2, boundaryLookup map[Vector2]bool) Com
 	for j := 0; j < M; j++ {
 		rawGrid[j] = make([]int, N)
 		for i := 0; i < N; i++ {
-			testP := Vector2{x: sortedX[i] + 1, y: sortedY[j] + 1}
+			testP := aocshared.Vec2[int]{X: sortedX[i] + 1, Y: sortedY[j] + 1}
 
 			if !tempShape.IsValidCoord(testP) {
 				rawGrid[j][i] = 1
@@ -101,7 +98,7 @@ func buildCompressedSpace(coords []Vector2, boundaryLookup map[Vector2]bool) Com
 
 type Day9 struct {
 	input    string
-	coords   []Vector2
+	coords   []aocshared.Vec2[int]
 	shape    Shape
 	pairsPt1 []Pair
 	pairsPt2 []Pair
@@ -114,17 +111,17 @@ type Pair struct {
 }
 
 type Shape struct {
-	coords          []Vector2
-	boundaryLookup  map[Vector2]bool
+	coords          []aocshared.Vec2[int]
+	boundaryLookup  map[aocshared.Vec2[int]]bool
 	compressedSpace CompressedSpace
 }
 
-func (s Shape) isOnBoundary(coord Vector2) bool {
+func (s Shape) isOnBoundary(coord aocshared.Vec2[int]) bool {
 	_, ok := s.boundaryLookup[coord]
 	return ok
 }
 
-func (s Shape) countVerticalCrossings(coord Vector2) int {
+func (s Shape) countVerticalCrossings(coord aocshared.Vec2[int]) int {
 	numCrossings := 0
 	N := len(s.coords)
 
@@ -132,17 +129,17 @@ func (s Shape) countVerticalCrossings(coord Vector2) int {
 		V_A := s.coords[i]
 		V_B := s.coords[(i+1)%N]
 
-		if V_A.x == V_B.x {
-			wallX := V_A.x
+		if V_A.X == V_B.X {
+			wallX := V_A.X
 
-			minY := V_A.y
-			maxY := V_B.y
+			minY := V_A.Y
+			maxY := V_B.Y
 			if minY > maxY {
 				minY, maxY = maxY, minY
 			}
 
-			if wallX > coord.x {
-				if coord.y >= minY && coord.y < maxY {
+			if wallX > coord.X {
+				if coord.Y >= minY && coord.Y < maxY {
 					numCrossings++
 				}
 			}
@@ -152,7 +149,7 @@ func (s Shape) countVerticalCrossings(coord Vector2) int {
 	return numCrossings
 }
 
-func (s Shape) IsValidCoord(coord Vector2) bool {
+func (s Shape) IsValidCoord(coord aocshared.Vec2[int]) bool {
 	if s.isOnBoundary(coord) {
 		return true
 	}
@@ -164,8 +161,8 @@ func (s Shape) IsValidCoord(coord Vector2) bool {
 	return isInside
 }
 
-func buildBoundaryLookup(coords []Vector2) map[Vector2]bool {
-	boundaryMap := make(map[Vector2]bool)
+func buildBoundaryLookup(coords []aocshared.Vec2[int]) map[aocshared.Vec2[int]]bool {
+	boundaryMap := make(map[aocshared.Vec2[int]]bool)
 	N := len(coords)
 
 	if N < 2 {
@@ -178,8 +175,8 @@ func buildBoundaryLookup(coords []Vector2) map[Vector2]bool {
 
 		boundaryMap[V_A] = true
 
-		dx := V_B.x - V_A.x
-		dy := V_B.y - V_A.y
+		dx := V_B.X - V_A.X
+		dy := V_B.Y - V_A.Y
 
 		if dy == 0 {
 			stepX := 1
@@ -187,8 +184,8 @@ func buildBoundaryLookup(coords []Vector2) map[Vector2]bool {
 				stepX = -1
 			}
 
-			for x := V_A.x; x != V_B.x; x += stepX {
-				boundaryMap[Vector2{x: x, y: V_A.y}] = true
+			for x := V_A.X; x != V_B.X; x += stepX {
+				boundaryMap[aocshared.Vec2[int]{X: x, Y: V_A.Y}] = true
 			}
 		}
 
@@ -198,8 +195,8 @@ func buildBoundaryLookup(coords []Vector2) map[Vector2]bool {
 				stepY = -1
 			}
 
-			for y := V_A.y; y != V_B.y; y += stepY {
-				boundaryMap[Vector2{x: V_A.x, y: y}] = true
+			for y := V_A.Y; y != V_B.Y; y += stepY {
+				boundaryMap[aocshared.Vec2[int]{X: V_A.X, Y: y}] = true
 			}
 		}
 
@@ -209,7 +206,7 @@ func buildBoundaryLookup(coords []Vector2) map[Vector2]bool {
 	return boundaryMap
 }
 
-func ShapeBuild(coords []Vector2) Shape {
+func ShapeBuild(coords []aocshared.Vec2[int]) Shape {
 	boundaryLookup := buildBoundaryLookup(coords)
 
 	shape := Shape{
@@ -222,78 +219,35 @@ func ShapeBuild(coords []Vector2) Shape {
 	return shape
 }
 
-type Vector2 struct {
-	x, y int
-}
-
-func (v Vector2) Subtract(other Vector2) Vector2 {
-	return Vector2{
-		x: v.x - other.x,
-		y: v.y - other.y,
-	}
-}
-
-func (v Vector2) AbsoluteDifference(other Vector2) Vector2 {
-	difference := v.Subtract(other)
-
-	differenceX := difference.x
-	differenceY := difference.y
-
-	if differenceX < 0 {
-		differenceX = -differenceX
-	}
-
-	if differenceY < 0 {
-		differenceY = -differenceY
-	}
-
-	return Vector2{
-		x: differenceX,
-		y: differenceY,
-	}
-}
-
-func (v Vector2) Area(other Vector2) int {
-	absoluteDifference := v.AbsoluteDifference(other)
-	width := absoluteDifference.x
-	height := absoluteDifference.y
-
-	width += 1
-	height += 1
-
-	return width * height
-}
-
-func (v Vector2) EuclidianDistance(other Vector2) float64 {
-	return math.Sqrt(v.EuclidianDistanceSquared(other))
-}
-
-func (v Vector2) EuclidianDistanceSquared(other Vector2) float64 {
-	dx := float64(v.x - other.x)
-	dy := float64(v.y - other.y)
-
-	return dx*dx + dy*dy
+// rectangleArea counts the tiles of the rectangle with opposite corners a and b, both included.
+func rectangleArea(a, b aocshared.Vec2[int]) int {
+	size := a.Sub(b).Abs()
+	return (size.X + 1) * (size.Y + 1)
 }
 
 func (d *Day9) GatherInput() {
+	var err error
+
 	if test {
-		d.input = aocshared.GetTestInput(2025, 9)
+		d.input, err = aocshared.GetTestInput(2025, 9)
 	} else {
-		d.input = aocshared.GetInput(2025, 9)
+		d.input, err = aocshared.GetInput(2025, 9)
+	}
+
+	if err != nil {
+		panic(err)
 	}
 }
 
 func (d *Day9) ParseInput() {
-	lines := strings.Split(d.input, "\n")
-
-	allCoords := make([]Vector2, len(lines))
-	for i, line := range lines {
-		coords := strings.Split(line, ",")
-
-		x, _ := strconv.Atoi(coords[0])
-		y, _ := strconv.Atoi(coords[1])
+	tuples, err := aocshared.ParseTuples(d.input, 2)
+	if err != nil {
+		panic(err)
+	}
 
-		allCoords[i] = Vector2{x: x, y: y}
+	allCoords := make([]aocshared.Vec2[int], len(tuples))
+	for i, tuple := range tuples {
+		allCoords[i] = aocshared.Vec2[int]{X: tuple[0], Y: tuple[1]}
 	}
 
 	d.coords = allCoords
@@ -303,18 +257,18 @@ func (d *Day9) PreparePairs() {
 	d.shape = ShapeBuild(d.coords)
 }
 
-func isRectangleValid(shape Shape, cornerA, cornerB Vector2) bool {
-	bottomRight := Vector2{x: cornerB.x, y: cornerA.y}
-	topLeft := Vector2{x: cornerA.x, y: cornerB.y}
+func isRectangleValid(shape Shape, cornerA, cornerB aocshared.Vec2[int]) bool {
+	bottomRight := aocshared.Vec2[int]{X: cornerB.X, Y: cornerA.Y}
+	topLeft := aocshared.Vec2[int]{X: cornerA.X, Y: cornerB.Y}
 
 	if !shape.IsValidCoord(bottomRight) || !shape.IsValidCoord(topLeft) {
 		return false
 	}
 
-	minXRank := min(shape.compressedSpace.xRanks[cornerA.x], shape.compressedSpace.xRanks[cornerB.x])
-	maxXRank := max(shape.compressedSpace.xRanks[cornerA.x], shape.compressedSpace.xRanks[cornerB.x])
-	minYRank := min(shape.compressedSpace.yRanks[cornerA.y], shape.compressedSpace.yRanks[cornerB.y])
-	maxYRank := max(shape.compressedSpace.yRanks[cornerA.y], shape.compressedSpace.yRanks[cornerB.y])
+	minXRank := min(shape.compressedSpace.xRanks[cornerA.X], shape.compressedSpace.xRanks[cornerB.X])
+	maxXRank := max(shape.compressedSpace.xRanks[cornerA.X], shape.compressedSpace.xRanks[cornerB.X])
+	minYRank := min(shape.compressedSpace.yRanks[cornerA.Y], shape.compressedSpace.yRanks[cornerB.Y])
+	maxYRank := max(shape.compressedSpace.yRanks[cornerA.Y], shape.compressedSpace.yRanks[cornerB.Y])
 
 	r2, c2 := maxYRank, maxXRank
 	r1, c1 := minYRank, minXRank
@@ -339,16 +293,16 @@ func (d *Day9) BuildPairs() {
 			pt1Pairs = append(pt1Pairs, Pair{
 				idxA:     i,
 				idxB:     j,
-				distance: coordA.EuclidianDistance(coordB),
-				area:     coordA.Area(coordB),
+				distance: coordA.Euclidean(coordB),
+				area:     rectangleArea(coordA, coordB),
 			})
 
 			if isRectangleValid(d.shape, coordA, coordB) {
 				pt2Pairs = append(pt2Pairs, Pair{
 					idxA:     i,
 					idxB:     j,
-					distance: coordA.EuclidianDistance(coordB),
-					area:     coordA.Area(coordB),
+					distance: coordA.Euclidean(coordB),
+					area:     rectangleArea(coordA, coordB),
 				})
 			}
 		}
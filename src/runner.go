@@ -1,6 +1,8 @@
 package main
 
 import (
+	aocshared "aoc_shared"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -25,7 +27,10 @@ func main() {
 }
 
 func runSolution(year, day int) error {
-	targetDir := filepath.Join("src", fmt.Sprintf("%d", year), fmt.Sprintf("day%d", day))
+	targetDir, err := aocshared.PuzzleDir(year, day)
+	if err != nil {
+		return err
+	}
 
 	scriptPath := filepath.Join(targetDir, "main.go")
 	if _, err := os.Stat(scriptPath); os.IsNotExist(err) {
@@ -34,6 +39,8 @@ func runSolution(year, day int) error {
 
 	fmt.Printf("--- Running Year %d Day %d ---\n", year, day)
 
+	warnOnInputChecksum(year, day)
+
 	cmd := exec.Command("go", "run", "main.go")
 
 	cmd.Dir = targetDir
@@ -43,6 +50,34 @@ func runSolution(year, day int) error {
 	return cmd.Run()
 }
 
+// warnOnInputChecksum compares input.txt with the downloader's manifest, flagging accidental edits.
+func warnOnInputChecksum(year, day int) {
+	data, err := aocshared.GetInputRaw(year, day)
+	if err != nil {
+		fmt.Printf("WARNING: could not read input.txt: %v\n", err)
+		return
+	}
+
+	root, err := aocshared.RepoRoot()
+	if err != nil {
+		fmt.Printf("WARNING: could not locate input manifest: %v\n", err)
+		return
+	}
+
+	manifest, err := aocshared.InputManifestLoad(filepath.Join(root, "src", aocshared.InputManifestFileName))
+	if err != nil {
+		fmt.Printf("WARNING: could not load input manifest: %v\n", err)
+		return
+	}
+
+	err = aocshared.InputManifestVerify(manifest, aocshared.InputManifestKey(year, day), data)
+	if errors.Is(err, aocshared.ErrInputUnrecorded) {
+		fmt.Println("WARNING: input.txt has no recorded checksum; re-download it to record one.")
+	} else if err != nil {
+		fmt.Printf("WARNING: input.txt differs from the downloaded file (%v)\n", err)
+	}
+}
+
 func getUserInput() (int, int) {
 	var year, day int
 
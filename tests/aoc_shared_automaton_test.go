@@ -0,0 +1,93 @@
+package tests
+
+import (
+	aocshared "aoc_shared"
+	"strings"
+	"testing"
+)
+
+func testAutomaton(t *testing.T) {
+	life := func(alive bool, neighbors [8]bool, x, y int) bool {
+		count := 0
+		for _, neighbor := range neighbors {
+			if neighbor {
+				count++
+			}
+		}
+		return count == 3 || (alive && count == 2)
+	}
+	parse := func(rows ...string) *aocshared.Grid[bool] {
+		grid, _, err := aocshared.GridParse(strings.Join(rows, "\n"), map[rune]bool{'.': false, '#': true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return grid
+	}
+	sameCells := func(a, b *aocshared.Grid[bool]) bool {
+		for p, cell := range a.All() {
+			if other, _ := b.At(p.X, p.Y); other != cell {
+				return false
+			}
+		}
+		return true
+	}
+
+	// Test 1: A blinker oscillates with period two
+	t.Run("blinker", func(t *testing.T) {
+		start := parse(".....", "..#..", "..#..", "..#..", ".....")
+		automaton := aocshared.AutomatonCreate(aocshared.GridClone(start), life)
+
+		assertEqual(t, aocshared.AutomatonStep(automaton), 4, "two cells die, two are born")
+		horizontal, _ := aocshared.AutomatonGrid(automaton).At(1, 2)
+		assertTrue(t, horizontal, "the blinker should lie flat", "blinker turned")
+
+		assertEqual(t, aocshared.AutomatonRun(automaton, 3), 12, "changes over three more generations")
+		assertEqual(t, aocshared.AutomatonGeneration(automaton), 4, "generation count")
+		assertTrue(t, sameCells(aocshared.AutomatonGrid(automaton), start), "an even generation should match the start", "period two")
+	})
+
+	// Test 2: Running until stable stops on the first generation without changes
+	t.Run("until_stable", func(t *testing.T) {
+		automaton := aocshared.AutomatonCreate(parse("##..", "#...", "....", "...#"), life)
+
+		assertEqual(t, aocshared.AutomatonRunUntilStable(automaton), 2, "one birth and one death")
+		assertEqual(t, aocshared.AutomatonGeneration(automaton), 2, "the unchanged generation is counted")
+		assertEqual(t, aocshared.GridCount(aocshared.AutomatonGrid(automaton), func(alive bool) bool { return alive }), 4, "settled into a block")
+	})
+
+	// Test 3: Tracking active cells gives the same generations as full passes
+	t.Run("active", func(t *testing.T) {
+		start := parse(
+			"..........",
+			"...#......",
+			"....#.....",
+			"..###.....",
+			"..........",
+			"..........",
+			"......##..",
+			"......##..",
+			"..........",
+			"..........",
+		)
+		full := aocshared.AutomatonCreate(aocshared.GridClone(start), life)
+		active := aocshared.AutomatonCreate(aocshared.GridClone(start), life)
+		aocshared.AutomatonTrackActive(active)
+
+		for generation := 0; generation < 12; generation++ {
+			assertEqual(t, aocshared.AutomatonStep(active), aocshared.AutomatonStep(full), "same number of changes")
+		}
+		assertTrue(t, sameCells(aocshared.AutomatonGrid(active), aocshared.AutomatonGrid(full)), "tracked run should match the full run", "tracked run matched")
+	})
+
+	// Test 4: Neighbours follow the grid's wrap mode
+	t.Run("wrap", func(t *testing.T) {
+		start := parse(".#....", "..#...", "###...", "......", "......", "......")
+		grid := aocshared.GridClone(start)
+		grid.SetWrap(aocshared.WrapBoth)
+
+		automaton := aocshared.AutomatonCreate(grid, life)
+		aocshared.AutomatonTrackActive(automaton)
+		aocshared.AutomatonRun(automaton, 24)
+		assertTrue(t, sameCells(aocshared.AutomatonGrid(automaton), start), "a glider should cross a 6x6 torus in 24 generations", "glider came back around")
+	})
+}
@@ -0,0 +1,128 @@
+package tests
+
+import (
+	aocshared "aoc_shared"
+	"testing"
+)
+
+func testBitGrid(t *testing.T) {
+	// randomGrid fills a Grid[bool] wider than one word with a fixed pseudo-random pattern.
+	randomGrid := func(width, height int) *aocshared.Grid[bool] {
+		state := uint32(7)
+		rows := make([][]bool, height)
+		for y := range rows {
+			rows[y] = make([]bool, width)
+			for x := range rows[y] {
+				state = state*1664525 + 1013904223
+				rows[y][x] = state>>29 < 3
+			}
+		}
+		return aocshared.GridCreate(rows)
+	}
+
+	// Test 1: Conversion both ways keeps every cell
+	t.Run("conversion", func(t *testing.T) {
+		grid := randomGrid(130, 5)
+		bitGrid := aocshared.BitGridFromGrid(grid)
+		assertEqual(t, bitGrid.Width(), 130, "width")
+		assertEqual(t, bitGrid.Height(), 5, "height")
+		assertEqual(t, len(bitGrid.Row(0)), 3, "three words per row")
+
+		back := aocshared.BitGridToGrid(bitGrid)
+		same := true
+		for p, cell := range grid.All() {
+			other, _ := back.At(p.X, p.Y)
+			bit, _ := bitGrid.At(p.X, p.Y)
+			same = same && other == cell && bit == cell
+		}
+		assertTrue(t, same, "round trip should keep every cell", "round trip kept every cell")
+		assertEqual(t, bitGrid.Count(), aocshared.GridCount(grid, func(cell bool) bool { return cell }), "popcount")
+	})
+
+	// Test 2: Neighbour counts match the adjacencies of the plain grid, edges and word boundaries included
+	t.Run("neighbor_count", func(t *testing.T) {
+		grid := randomGrid(130, 6)
+		bitGrid := aocshared.BitGridFromGrid(grid)
+
+		mismatches := 0
+		for p := range grid.All() {
+			expected := 0
+			for _, adjacent := range grid.Adjacencies(p.X, p.Y) {
+				if adjacent {
+					expected++
+				}
+			}
+			if bitGrid.NeighborCount(p.X, p.Y) != expected {
+				mismatches++
+			}
+		}
+		assertEqual(t, mismatches, 0, "neighbour counts")
+
+		line := aocshared.BitGridCreate(4, 1)
+		line.Set(0, 0, true)
+		line.Set(1, 0, true)
+		assertEqual(t, line.NeighborCount(-1, 0), 1, "one cell left of the row")
+		assertEqual(t, line.NeighborCount(-2, 0), 0, "two cells left of the row")
+		assertEqual(t, line.NeighborCount(-5, 0), 0, "far left of the row")
+		assertEqual(t, line.NeighborCount(-70, 0), 0, "more than a word left of the row")
+		assertEqual(t, line.NeighborCount(0, -1), 2, "the row below")
+		assertEqual(t, line.NeighborCount(5, 0), 0, "right of the row")
+		assertEqual(t, aocshared.BitGridCreate(0, 3).NeighborCount(0, 1), 0, "zero-width grid")
+	})
+
+	// Test 3: Shape masks across a word boundary
+	t.Run("shapes", func(t *testing.T) {
+		bitGrid := aocshared.BitGridCreate(130, 4)
+		shape, err := aocshared.BitShapeCreate([]aocshared.ShapeOffset{{DX: 0, DY: 0}, {DX: 1, DY: 0}, {DX: 2, DY: 0}, {DX: 1, DY: 1}})
+		assertTrue(t, err == nil, "shape should pack", "shape packed")
+
+		assertTrue(t, aocshared.BitGridFits(bitGrid, shape, 62, 1), "shape should fit on a clear grid", "fits")
+		aocshared.BitGridPlace(bitGrid, shape, 62, 1)
+		assertEqual(t, bitGrid.Count(), 4, "cells placed")
+		for _, x := range []int{62, 63, 64} {
+			cell, _ := bitGrid.At(x, 1)
+			assertTrue(t, cell, "top row should straddle both words", "cell set")
+		}
+		assertFalse(t, aocshared.BitGridFits(bitGrid, shape, 64, 1), "overlapping shape should not fit", "overlap rejected")
+		assertTrue(t, aocshared.BitGridFits(bitGrid, shape, 65, 1), "neighbouring shape should fit", "neighbour fits")
+		assertFalse(t, aocshared.BitGridFits(bitGrid, shape, 128, 0), "shape past the edge should not fit", "edge rejected")
+
+		aocshared.BitGridRemove(bitGrid, shape, 62, 1)
+		assertEqual(t, bitGrid.Count(), 0, "shape removed")
+
+		_, err = aocshared.BitShapeCreate([]aocshared.ShapeOffset{{DX: 0, DY: 0}, {DX: 64, DY: 0}})
+		assertTrue(t, err != nil, "shapes wider than a word should be rejected", "wide shape rejected")
+	})
+
+	// Test 4: Packing agrees with the generic search
+	t.Run("can_fit_shapes", func(t *testing.T) {
+		shapes := map[int][]aocshared.ShapeOffset{
+			0: {{DX: 0, DY: 0}, {DX: 1, DY: 0}, {DX: 2, DY: 0}, {DX: 0, DY: 1}, {DX: 0, DY: 2}, {DX: 1, DY: 2}, {DX: 2, DY: 2}},
+			1: {{DX: 0, DY: 0}, {DX: 1, DY: 0}, {DX: 1, DY: 1}, {DX: 2, DY: 1}, {DX: 2, DY: 2}},
+			2: {{DX: 0, DY: 0}, {DX: 1, DY: 0}, {DX: 0, DY: 1}},
+		}
+		flags := aocshared.PlacementFlags{AllowRotate: true, AllowFlip: true}
+
+		fitted := 0
+		for _, region := range []struct{ width, height, count int }{{3, 3, 1}, {5, 5, 1}, {4, 3, 1}, {7, 6, 2}, {6, 5, 2}} {
+			counts := map[int]int{0: region.count, 1: region.count, 2: region.count}
+
+			rows := make([][]bool, region.height)
+			for y := range rows {
+				rows[y] = make([]bool, region.width)
+			}
+			expected := aocshared.GridCanFitShapes(aocshared.GridCreate(rows), shapes, counts, flags,
+				func(cell bool, x, y int) bool { return !cell }, true)
+
+			bitGrid := aocshared.BitGridCreate(region.width, region.height)
+			fits, err := aocshared.BitGridCanFitShapes(bitGrid, shapes, counts, flags)
+			assertTrue(t, err == nil, "packing should run", "packing ran")
+			assertEqual(t, fits, expected, "same answer as GridCanFitShapes")
+			if fits {
+				fitted++
+				assertEqual(t, bitGrid.Count(), 15*region.count, "placed shapes are left on the grid")
+			}
+		}
+		assertTrue(t, fitted > 0 && fitted < 5, "regions should cover both outcomes", "both outcomes covered")
+	})
+}
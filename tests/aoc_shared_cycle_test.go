@@ -0,0 +1,134 @@
+package tests
+
+import (
+	aocshared "aoc_shared"
+	"testing"
+)
+
+func testCycleDetection(t *testing.T) {
+	next := func(x int) int { return (x*x + 1) % 255 }
+	key := func(x int) uint64 { return uint64(x) }
+
+	// bruteForce walks n steps the slow way.
+	bruteForce := func(n int) int {
+		x := 3
+		for range n {
+			x = next(x)
+		}
+		return x
+	}
+
+	// Test 1: Both finders agree with a direct search for the first repeat
+	t.Run("find", func(t *testing.T) {
+		seen := map[int]int{}
+		x, n := 3, 0
+		for {
+			if _, ok := seen[x]; ok {
+				break
+			}
+			seen[x] = n
+			x, n = next(x), n+1
+		}
+		expected := aocshared.Cycle{Start: seen[x], Length: n - seen[x]}
+
+		assertEqual(t, aocshared.CycleFind(3, next, key), expected, "hash map finder")
+		assertEqual(t, aocshared.CycleFindBrent(3, next, key), expected, "Brent's algorithm")
+		assertTrue(t, expected.Start > 0, "the sequence should have a tail before the loop", "tail present")
+	})
+
+	// Test 2: Extrapolating far ahead lands on the same state as walking there
+	t.Run("extrapolate", func(t *testing.T) {
+		cycle := aocshared.CycleFind(3, next, key)
+		far := cycle.Start + 10*cycle.Length + 3
+		assertEqual(t, aocshared.CycleStateAt(3, next, key, far), bruteForce(far), "state past the cycle")
+		assertEqual(t, aocshared.CycleStateAt(3, next, key, 1), bruteForce(1), "state before the cycle")
+		assertEqual(t, cycle.Index(far), cycle.Start+3, "index folded into the first lap")
+
+		parity := func(x int) bool { return x%2 == 0 }
+		assertEqual(t, aocshared.CycleMetricAt(3, next, key, parity, 1_000_000_000), parity(bruteForce(cycle.Index(1_000_000_000))), "metric after a billion steps")
+	})
+
+	// Test 3: Grids as states through their fingerprint
+	t.Run("grid", func(t *testing.T) {
+		life := func(alive bool, neighbors [8]bool, x, y int) bool {
+			count := 0
+			for _, neighbor := range neighbors {
+				if neighbor {
+					count++
+				}
+			}
+			return count == 3 || (alive && count == 2)
+		}
+		step := func(grid *aocshared.Grid[bool]) *aocshared.Grid[bool] {
+			automaton := aocshared.AutomatonCreate(aocshared.GridClone(grid), life)
+			aocshared.AutomatonStep(automaton)
+			return aocshared.AutomatonGrid(automaton)
+		}
+		fingerprint := func(grid *aocshared.Grid[bool]) uint64 { return aocshared.GridFingerprint[bool](grid) }
+
+		// A vertical blinker that turns flat and back every generation.
+		start := aocshared.GridCreate([][]bool{
+			{false, true, false, false, false},
+			{false, true, false, false, false},
+			{false, true, false, false, false},
+			{false, false, false, false, false},
+			{false, false, false, false, false},
+		})
+		cycle := aocshared.CycleFind(start, step, fingerprint)
+		assertEqual(t, cycle, aocshared.CycleFindBrent(start, step, fingerprint), "finders agree on grids")
+		assertEqual(t, cycle.Length, 2, "blinker period")
+
+		count := func(grid *aocshared.Grid[bool]) int {
+			return aocshared.GridCount(grid, func(alive bool) bool { return alive })
+		}
+		assertEqual(t, aocshared.CycleMetricAt(start, step, fingerprint, count, 999_999_999), count(step(start)), "population after an odd number of steps")
+	})
+
+	// Test 4: Fingerprints follow contents and shape
+	t.Run("fingerprint", func(t *testing.T) {
+		a := aocshared.GridCreate([][]int{{1, 2}, {3, 4}})
+		b := aocshared.GridClone(a)
+		assertEqual(t, aocshared.GridFingerprint[int](a), aocshared.GridFingerprint[int](b), "equal grids")
+
+		b.Set(1, 1, 5)
+		assertTrue(t, aocshared.GridFingerprint[int](a) != aocshared.GridFingerprint[int](b), "a changed cell should change the fingerprint", "contents hashed")
+
+		flat := aocshared.GridCreate([][]int{{1, 2, 3, 4}})
+		assertTrue(t, aocshared.GridFingerprint[int](a) != aocshared.GridFingerprint[int](flat), "the shape should change the fingerprint", "shape hashed")
+
+		sparse := aocshared.SparseGridCreate(0)
+		sparse.Set(-3, 2, 7)
+		moved := aocshared.SparseGridCreate(0)
+		moved.Set(3, 2, 7)
+		assertTrue(t, aocshared.GridFingerprint[int](sparse) != aocshared.GridFingerprint[int](moved), "sparse cells should be hashed with their position", "sparse positions hashed")
+	})
+
+	// Test 5: Fingerprints are the same in every run
+	t.Run("fingerprint_stable", func(t *testing.T) {
+		grid := aocshared.GridCreate([][]rune{[]rune("#."), []rune(".#")})
+		assertEqual(t, aocshared.GridFingerprint[rune](grid), uint64(3283913979903733836), "fixed fingerprint")
+	})
+
+	// Test 6: Struct cells are hashed field by field, pointer cells are rejected
+	t.Run("fingerprint_cell_kinds", func(t *testing.T) {
+		type tile struct {
+			Kind  string
+			Cost  uint8
+			Solid bool
+		}
+		a := aocshared.GridCreate([][]tile{{{"wall", 3, true}, {"floor", 1, false}}})
+		b := aocshared.GridClone(a)
+		assertEqual(t, aocshared.GridFingerprint[tile](a), aocshared.GridFingerprint[tile](b), "equal struct grids")
+		b.Set(1, 0, tile{"floor", 2, false})
+		assertTrue(t, aocshared.GridFingerprint[tile](a) != aocshared.GridFingerprint[tile](b), "a changed field should change the fingerprint", "struct fields hashed")
+
+		cell := 1
+		pointers := aocshared.GridCreate([][]*int{{&cell}})
+		panicked := func() (panicked bool) {
+			defer func() { panicked = recover() != nil }()
+			aocshared.GridFingerprint[*int](pointers)
+			return false
+		}()
+		assertTrue(t, panicked, "pointer cells should not be fingerprinted", "pointer cells rejected")
+	})
+}
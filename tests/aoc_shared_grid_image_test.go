@@ -0,0 +1,83 @@
+package tests
+
+import (
+	aocshared "aoc_shared"
+	"bytes"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"path/filepath"
+	"testing"
+)
+
+func testGridImages(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	cellColor := func(on bool) color.Color {
+		if on {
+			return red
+		}
+		return color.Black
+	}
+
+	// Test 1: PNG round trip
+	t.Run("png", func(t *testing.T) {
+		grid := aocshared.GridCreate([][]bool{{true, false, false}, {false, false, true}})
+
+		var buf bytes.Buffer
+		err := aocshared.GridEncodePNG(&buf, grid, 3, cellColor)
+		assertTrue(t, err == nil, "encoding should succeed", "encoded")
+
+		img, err := png.Decode(&buf)
+		assertTrue(t, err == nil, "PNG should decode", "decoded")
+		assertEqual(t, img.Bounds().Dx(), 9, "image width")
+		assertEqual(t, img.Bounds().Dy(), 6, "image height")
+		assertEqual(t, color.RGBAModel.Convert(img.At(2, 2)), color.Color(red), "first cell filled")
+		assertEqual(t, color.RGBAModel.Convert(img.At(3, 0)), color.RGBAModel.Convert(color.Black), "second cell empty")
+		assertEqual(t, color.RGBAModel.Convert(img.At(8, 5)), color.Color(red), "last cell filled")
+	})
+
+	// Test 2: Sparse grids are drawn over their bounding box
+	t.Run("sparse_png", func(t *testing.T) {
+		grid := aocshared.SparseGridCreate(false)
+		grid.Set(-2, -1, true)
+		grid.Set(1, 0, true)
+
+		img := aocshared.GridImage(grid, 1, cellColor)
+		assertEqual(t, img.Bounds().Dx(), 4, "bounding box width")
+		assertEqual(t, img.Bounds().Dy(), 2, "bounding box height")
+		assertEqual(t, color.Color(img.RGBAAt(0, 0)), color.Color(red), "negative corner drawn")
+	})
+
+	// Test 3: GIF frames and palette
+	t.Run("gif", func(t *testing.T) {
+		grid := aocshared.GridCreate([][]bool{{true, true}, {true, true}})
+		recorder := aocshared.GridRecorderCreate(2, 5, color.Palette{color.Black, red}, cellColor)
+
+		for step := 0; step < 3; step++ {
+			aocshared.GridRecorderAddFrame(recorder, grid)
+			grid.Set(step%2, step/2, false)
+		}
+		assertEqual(t, aocshared.GridRecorderFrames(recorder), 3, "frames recorded")
+
+		path := filepath.Join(t.TempDir(), "nested", "grid.gif")
+		err := aocshared.GridRecorderSaveGIF(path, recorder)
+		assertTrue(t, err == nil, "saving should succeed", "saved")
+
+		var buf bytes.Buffer
+		assertTrue(t, aocshared.GridRecorderEncodeGIF(&buf, recorder) == nil, "encoding should succeed", "encoded")
+		animation, err := gif.DecodeAll(&buf)
+		assertTrue(t, err == nil, "GIF should decode", "decoded")
+		assertEqual(t, len(animation.Image), 3, "frame count")
+		assertEqual(t, animation.Delay[0], 5, "frame delay")
+		assertEqual(t, animation.Config.Width, 4, "cell size applied")
+		assertEqual(t, animation.Image[2].ColorIndexAt(0, 0), uint8(0), "cleared cell uses the first palette colour")
+		assertEqual(t, animation.Image[2].ColorIndexAt(3, 3), uint8(1), "filled cell uses the second palette colour")
+	})
+
+	// Test 4: Nothing to encode
+	t.Run("empty_recording", func(t *testing.T) {
+		recorder := aocshared.GridRecorderCreate(1, 1, nil, cellColor)
+		var buf bytes.Buffer
+		assertTrue(t, aocshared.GridRecorderEncodeGIF(&buf, recorder) != nil, "an empty recording should fail", "empty recording rejected")
+	})
+}
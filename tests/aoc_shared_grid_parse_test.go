@@ -0,0 +1,68 @@
+package tests
+
+import (
+	aocshared "aoc_shared"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func testGridParsing(t *testing.T) {
+	cells := map[rune]int{'.': 0, '#': 1, 'S': 2}
+
+	// Test 1: Cells, markers and trailing blank lines
+	t.Run("cells_and_markers", func(t *testing.T) {
+		grid, markers, err := aocshared.GridParse("S.#\r\n#S.\n\n", cells, 'S', '#', 'E')
+		assertTrue(t, err == nil, "GridParse should succeed", "GridParse succeeded")
+
+		cell, _ := aocshared.GridGetAt(*grid, 2, 0)
+		assertEqual(t, cell, 1, "wall cell")
+		_, err = aocshared.GridGetAt(*grid, 0, 2)
+		assertTrue(t, err != nil, "trailing blank lines add no rows", "no extra rows")
+
+		assertEqual(t, len(markers['S']), 2, "start count")
+		assertEqual(t, markers['S'][1], aocshared.Point{X: 1, Y: 1}, "second start")
+		assertEqual(t, markers['#'][0], aocshared.Point{X: 2, Y: 0}, "first wall in reading order")
+		assertEqual(t, len(markers['E']), 0, "absent marker")
+	})
+
+	// Test 2: Ragged rows are padded with the zero cell
+	t.Run("ragged", func(t *testing.T) {
+		grid, _, err := aocshared.GridParse("#\n###\n##", cells)
+		assertTrue(t, err == nil, "GridParse should succeed", "GridParse succeeded")
+
+		cell, err := aocshared.GridGetAt(*grid, 2, 0)
+		assertTrue(t, err == nil, "short row is padded to full width", "short row padded")
+		assertEqual(t, cell, 0, "padding is the zero cell")
+	})
+
+	// Test 3: Unknown characters point at their row and column
+	t.Run("unknown_character", func(t *testing.T) {
+		_, _, err := aocshared.GridParse("..\n.x", cells)
+
+		var parseErr *aocshared.ParseError
+		assertTrue(t, errors.As(err, &parseErr), "unknown character should be a ParseError", "unknown character reported")
+		assertEqual(t, parseErr.Line, 2, "unknown character row")
+		assertEqual(t, parseErr.Column, 2, "unknown character column")
+	})
+
+	// Test 4: Conversion function
+	t.Run("func", func(t *testing.T) {
+		digits := func(char rune) (int, error) {
+			if char < '0' || char > '9' {
+				return 0, fmt.Errorf("not a digit")
+			}
+			return int(char - '0'), nil
+		}
+
+		grid, _, err := aocshared.GridParseFunc("12\n34", digits)
+		assertTrue(t, err == nil, "GridParseFunc should succeed", "GridParseFunc succeeded")
+		cell, _ := aocshared.GridGetAt(*grid, 1, 1)
+		assertEqual(t, cell, 4, "converted cell")
+
+		_, _, err = aocshared.GridParseFunc("1a", digits)
+		var parseErr *aocshared.ParseError
+		assertTrue(t, errors.As(err, &parseErr), "conversion error should be a ParseError", "conversion error reported")
+		assertEqual(t, parseErr.Column, 2, "conversion error column")
+	})
+}
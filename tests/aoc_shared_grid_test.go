@@ -0,0 +1,142 @@
+package tests
+
+import (
+	aocshared "aoc_shared"
+	"testing"
+)
+
+func testGridMethods(t *testing.T) {
+	newGrid := func() *aocshared.Grid[int] {
+		return aocshared.GridCreate([][]int{
+			{1, 2, 3},
+			{4, 5, 6},
+		})
+	}
+
+	// Test 1: Size, bounds and access
+	t.Run("access", func(t *testing.T) {
+		grid := newGrid()
+		assertEqual(t, grid.Width(), 3, "width")
+		assertEqual(t, grid.Height(), 2, "height")
+		assertTrue(t, grid.InBounds(2, 1), "bottom right should be in bounds", "bottom right in bounds")
+		assertFalse(t, grid.InBounds(3, 0), "x past the width should be out of bounds", "x checked")
+		assertFalse(t, grid.InBounds(0, -1), "negative y should be out of bounds", "y checked")
+
+		assertTrue(t, grid.Set(1, 1, 50) == nil, "Set should succeed", "Set succeeded")
+		cell, err := grid.At(1, 1)
+		assertTrue(t, err == nil, "At should succeed", "At succeeded")
+		assertEqual(t, cell, 50, "value written by Set")
+
+		_, err = grid.At(-1, 0)
+		assertTrue(t, err != nil, "At out of bounds should fail", "At out of bounds rejected")
+		assertTrue(t, grid.Set(0, 2, 0) != nil, "Set out of bounds should fail", "Set out of bounds rejected")
+	})
+
+	// Test 2: Free functions see the same cells
+	t.Run("wrappers", func(t *testing.T) {
+		grid := newGrid()
+		assertTrue(t, aocshared.GridSetAt(grid, 2, 0, 30) == nil, "GridSetAt should succeed", "GridSetAt succeeded")
+		cell, _ := grid.At(2, 0)
+		assertEqual(t, cell, 30, "GridSetAt writes through")
+
+		cell, _ = aocshared.GridGetAt(*grid, 0, 1)
+		assertEqual(t, cell, 4, "GridGetAt reads")
+		assertEqual(t, aocshared.GridGetAdjacencies(*grid, 0, 0), grid.Adjacencies(0, 0), "adjacencies match")
+	})
+
+	// Test 3: All iterates in reading order and stops early
+	t.Run("all", func(t *testing.T) {
+		grid := newGrid()
+
+		sum := 0
+		last := aocshared.Point{}
+		for p, cell := range grid.All() {
+			sum += cell
+			last = p
+		}
+		assertEqual(t, sum, 21, "every cell visited")
+		assertEqual(t, last, aocshared.Point{X: 2, Y: 1}, "reading order")
+
+		visited := 0
+		for range grid.All() {
+			visited++
+			if visited == 2 {
+				break
+			}
+		}
+		assertEqual(t, visited, 2, "early exit")
+	})
+
+	// Test 4: Neighbours by connectivity
+	t.Run("neighbors", func(t *testing.T) {
+		grid := newGrid()
+
+		orthogonal := make([]aocshared.Point, 0)
+		for p := range grid.Neighbors(aocshared.Point{X: 0, Y: 0}, aocshared.Conn4) {
+			orthogonal = append(orthogonal, p)
+		}
+		assertEqual(t, len(orthogonal), 2, "corner has two orthogonal neighbours")
+		assertEqual(t, orthogonal[0], aocshared.Point{X: 1, Y: 0}, "east first after the missing north")
+
+		diagonal := 0
+		for range grid.Neighbors(aocshared.Point{X: 1, Y: 0}, aocshared.Conn8) {
+			diagonal++
+		}
+		assertEqual(t, diagonal, 5, "edge cell has five neighbours")
+	})
+
+	// Test 5: Wrapping per axis
+	t.Run("wrap", func(t *testing.T) {
+		grid := newGrid()
+		grid.SetWrap(aocshared.WrapX)
+
+		cell, err := grid.At(-1, 0)
+		assertTrue(t, err == nil, "x should wrap", "x wraps")
+		assertEqual(t, cell, 3, "left of the first column is the last column")
+
+		_, err = grid.At(0, 2)
+		assertTrue(t, err != nil, "y should not wrap", "y does not wrap")
+
+		assertTrue(t, grid.Set(4, 1, 60) == nil, "Set should wrap", "Set wraps")
+		cell, _ = grid.At(1, 1)
+		assertEqual(t, cell, 60, "wrapped write")
+
+		grid.SetWrap(aocshared.WrapBoth)
+		assertEqual(t, grid.Adjacencies(0, 0), [8]int{4, 60, 2, 60, 4, 6, 3, 6}, "adjacencies wrap")
+
+		neighbours := 0
+		for range grid.Neighbors(aocshared.Point{X: 0, Y: 0}, aocshared.Conn4) {
+			neighbours++
+		}
+		assertEqual(t, neighbours, 4, "corner has four neighbours when wrapping")
+	})
+
+	// Test 6: Rays stop at edges, or after one lap when wrapping
+	t.Run("ray", func(t *testing.T) {
+		grid := newGrid()
+
+		seen := make([]aocshared.Point, 0)
+		for p := range grid.Ray(aocshared.Point{X: 0, Y: 0}, aocshared.E) {
+			seen = append(seen, p)
+		}
+		assertEqual(t, len(seen), 2, "ray stops at the edge")
+
+		grid.SetWrap(aocshared.WrapX)
+		seen = seen[:0]
+		for p := range grid.Ray(aocshared.Point{X: 1, Y: 0}, aocshared.W) {
+			seen = append(seen, p)
+		}
+		assertEqual(t, len(seen), 2, "ray stops after one lap")
+		assertEqual(t, seen[1], aocshared.Point{X: 2, Y: 0}, "ray wrapped")
+	})
+
+	// Test 7: Wrapping arithmetic
+	t.Run("wrap_index", func(t *testing.T) {
+		assertEqual(t, aocshared.WrapIndex(-1, 100), 99, "negative wraps from the top")
+		assertEqual(t, aocshared.WrapIndex(250, 100), 50, "large values wrap")
+
+		x, y := aocshared.NW.ApplyDeltaWrap(0, 0, 3, 2, aocshared.WrapY)
+		assertEqual(t, x, -1, "x left alone")
+		assertEqual(t, y, 1, "y wrapped")
+	})
+}
@@ -0,0 +1,178 @@
+package tests
+
+import (
+	aocshared "aoc_shared"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func testGridTransforms(t *testing.T) {
+	newGrid := func() *aocshared.Grid[int] {
+		return aocshared.GridCreate([][]int{
+			{1, 2, 3},
+			{4, 5, 6},
+		})
+	}
+	rowsOf := func(grid *aocshared.Grid[int]) string {
+		rows := make([][]int, 0, grid.Height())
+		for _, row := range grid.Rows() {
+			rows = append(rows, slices.Clone(row))
+		}
+		return fmt.Sprint(rows)
+	}
+
+	// Test 1: Rotations, transpose and flips
+	t.Run("transforms", func(t *testing.T) {
+		grid := newGrid()
+		assertEqual(t, rowsOf(aocshared.GridRotate90(grid)), fmt.Sprint([][]int{{4, 1}, {5, 2}, {6, 3}}), "rotated clockwise")
+		assertEqual(t, rowsOf(aocshared.GridRotate180(grid)), fmt.Sprint([][]int{{6, 5, 4}, {3, 2, 1}}), "rotated half a turn")
+		assertEqual(t, rowsOf(aocshared.GridRotate270(grid)), fmt.Sprint([][]int{{3, 6}, {2, 5}, {1, 4}}), "rotated counter-clockwise")
+		assertEqual(t, rowsOf(aocshared.GridTranspose(grid)), fmt.Sprint([][]int{{1, 4}, {2, 5}, {3, 6}}), "transposed")
+		assertEqual(t, rowsOf(aocshared.GridFlipHorizontal(grid)), fmt.Sprint([][]int{{3, 2, 1}, {6, 5, 4}}), "flipped left to right")
+		assertEqual(t, rowsOf(aocshared.GridFlipVertical(grid)), fmt.Sprint([][]int{{4, 5, 6}, {1, 2, 3}}), "flipped top to bottom")
+
+		full := aocshared.GridRotate90(aocshared.GridRotate90(aocshared.GridRotate90(aocshared.GridRotate90(grid))))
+		assertEqual(t, rowsOf(full), rowsOf(grid), "four quarter turns are the identity")
+		assertEqual(t, rowsOf(grid), fmt.Sprint([][]int{{1, 2, 3}, {4, 5, 6}}), "input untouched")
+
+		grid.SetWrap(aocshared.WrapX)
+		assertEqual(t, aocshared.GridRotate90(grid).Wrap(), aocshared.WrapY, "wrap follows the rotated axis")
+		assertEqual(t, aocshared.GridFlipVertical(grid).Wrap(), aocshared.WrapX, "flips keep the wrap mode")
+	})
+
+	// Test 2: Rows share storage, columns are copies
+	t.Run("rows_cols", func(t *testing.T) {
+		grid := newGrid()
+		row, _ := grid.Row(1)
+		assertEqual(t, fmt.Sprint(row), fmt.Sprint([]int{4, 5, 6}), "second row")
+		col, _ := grid.Col(2)
+		assertEqual(t, fmt.Sprint(col), fmt.Sprint([]int{3, 6}), "last column")
+
+		row, _ = grid.Row(0)
+		row[1] = 20
+		cell, _ := grid.At(1, 0)
+		assertEqual(t, cell, 20, "row writes through")
+
+		col, _ = grid.Col(0)
+		col[0] = 10
+		cell, _ = grid.At(0, 0)
+		assertEqual(t, cell, 1, "column is a copy")
+
+		row, _ = grid.Row(0)
+		_ = append(row, 99)
+		cell, _ = grid.At(0, 1)
+		assertEqual(t, cell, 4, "appending to a row does not clobber the next one")
+
+		for _, y := range []int{-1, 2} {
+			_, err := grid.Row(y)
+			assertTrue(t, err != nil, "row outside the grid should fail", "row outside rejected")
+		}
+		_, err := grid.Col(3)
+		assertTrue(t, err != nil, "column outside the grid should fail", "column outside rejected")
+
+		wrapped := newGrid()
+		wrapped.SetWrap(aocshared.WrapBoth)
+		row, err = wrapped.Row(-1)
+		assertTrue(t, err == nil, "rows wrap on a wrapping grid", "row wrapped")
+		assertEqual(t, fmt.Sprint(row), fmt.Sprint([]int{4, 5, 6}), "row -1 is the last row")
+		col, _ = wrapped.Col(3)
+		assertEqual(t, fmt.Sprint(col), fmt.Sprint([]int{1, 4}), "column 3 is the first column")
+
+		cols := 0
+		for x, col := range grid.Cols() {
+			assertEqual(t, len(col), 2, "column height")
+			cols = x + 1
+		}
+		assertEqual(t, cols, 3, "every column visited")
+	})
+
+	// Test 3: Views share storage with their parent
+	t.Run("view", func(t *testing.T) {
+		grid := aocshared.GridCreate([][]int{
+			{1, 2, 3, 4},
+			{5, 6, 7, 8},
+			{9, 10, 11, 12},
+		})
+		view, err := aocshared.GridViewCreate(grid, aocshared.Point{X: 1, Y: 1}, 2, 2)
+		assertTrue(t, err == nil, "view should fit", "view created")
+
+		cell, _ := view.At(0, 0)
+		assertEqual(t, cell, 6, "view coordinates are relative")
+		assertFalse(t, view.InBounds(2, 0), "view should clip at its own edge", "view clipped")
+		row, _ := view.Row(1)
+		assertEqual(t, fmt.Sprint(row), fmt.Sprint([]int{10, 11}), "view row")
+		col, _ := view.Col(1)
+		assertEqual(t, fmt.Sprint(col), fmt.Sprint([]int{7, 11}), "view column")
+		for _, x := range []int{-1, 2} {
+			_, err := view.Col(x)
+			assertTrue(t, err != nil, "column outside the view should fail", "column outside the view rejected")
+			_, err = view.Row(x)
+			assertTrue(t, err != nil, "row outside the view should fail", "row outside the view rejected")
+		}
+
+		view.Set(1, 1, 110)
+		cell, _ = grid.At(2, 2)
+		assertEqual(t, cell, 110, "view writes reach the parent")
+
+		neighbors := slices.Collect(view.Neighbors(aocshared.Point{X: 0, Y: 0}, aocshared.Conn8))
+		assertEqual(t, fmt.Sprint(neighbors), fmt.Sprint([]aocshared.Point{{X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}}), "neighbours stay inside the view")
+		assertEqual(t, aocshared.GridCount[int](view, func(cell int) bool { return cell > 6 }), 3, "helpers accept views")
+
+		_, err = aocshared.GridViewCreate(grid, aocshared.Point{X: 3, Y: 0}, 2, 1)
+		assertTrue(t, err != nil, "view past the edge should fail", "oversized view rejected")
+
+		sub, err := aocshared.GridSubGrid(grid, aocshared.Point{X: 2, Y: 0}, 2, 3)
+		assertTrue(t, err == nil, "sub-grid should fit", "sub-grid copied")
+		assertEqual(t, rowsOf(sub), fmt.Sprint([][]int{{3, 4}, {7, 8}, {110, 12}}), "sub-grid cells")
+		sub.Set(0, 0, 0)
+		cell, _ = grid.At(2, 0)
+		assertEqual(t, cell, 3, "sub-grid is a copy")
+	})
+
+	// Test 4: Tiling and concatenation
+	t.Run("tile_concat", func(t *testing.T) {
+		grid := newGrid()
+		assertEqual(t, rowsOf(aocshared.GridTile(grid, 2, 2)), fmt.Sprint([][]int{
+			{1, 2, 3, 1, 2, 3},
+			{4, 5, 6, 4, 5, 6},
+			{1, 2, 3, 1, 2, 3},
+			{4, 5, 6, 4, 5, 6},
+		}), "tiled two by two")
+
+		side := aocshared.GridCreate([][]int{{7}, {8}})
+		joined, err := aocshared.GridConcatHorizontal(grid, side)
+		assertTrue(t, err == nil, "equal heights should join", "joined side by side")
+		assertEqual(t, rowsOf(joined), fmt.Sprint([][]int{{1, 2, 3, 7}, {4, 5, 6, 8}}), "horizontal concatenation")
+
+		stacked, err := aocshared.GridConcatVertical(grid, aocshared.GridCreate([][]int{{7, 8, 9}}))
+		assertTrue(t, err == nil, "equal widths should stack", "stacked")
+		assertEqual(t, rowsOf(stacked), fmt.Sprint([][]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}), "vertical concatenation")
+
+		_, err = aocshared.GridConcatVertical(grid, side)
+		assertTrue(t, err != nil, "mismatched widths should fail", "mismatch rejected")
+		_, err = aocshared.GridConcatHorizontal[int]()
+		assertTrue(t, err != nil, "nothing to join should fail", "empty input rejected")
+	})
+
+	// Test 5: Cuts and joins keep the wrap only where edges still meet
+	t.Run("wrap_modes", func(t *testing.T) {
+		grid := newGrid()
+		grid.SetWrap(aocshared.WrapBoth)
+
+		rows, _ := aocshared.GridSubGrid(grid, aocshared.Point{}, 3, 1)
+		assertEqual(t, rows.Wrap(), aocshared.WrapX, "full-width cut keeps the horizontal wrap")
+		corner, _ := aocshared.GridSubGrid(grid, aocshared.Point{}, 2, 1)
+		assertEqual(t, corner.Wrap(), aocshared.WrapNone, "partial cut does not wrap")
+
+		side := aocshared.GridCreate([][]int{{7}, {8}})
+		joined, _ := aocshared.GridConcatHorizontal(grid, side)
+		assertEqual(t, joined.Wrap(), aocshared.WrapNone, "joined with a non-wrapping grid")
+		side.SetWrap(aocshared.WrapY)
+		joined, _ = aocshared.GridConcatHorizontal(grid, side)
+		assertEqual(t, joined.Wrap(), aocshared.WrapY, "side by side keeps the shared vertical wrap")
+
+		stacked, _ := aocshared.GridConcatVertical(grid, grid)
+		assertEqual(t, stacked.Wrap(), aocshared.WrapX, "stacked keeps the shared horizontal wrap")
+	})
+}
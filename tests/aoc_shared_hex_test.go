@@ -0,0 +1,138 @@
+package tests
+
+import (
+	aocshared "aoc_shared"
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func testHexGrid(t *testing.T) {
+	origin := aocshared.Hex{}
+
+	// Test 1: Comma separated flat-top paths and distances
+	t.Run("flat_paths", func(t *testing.T) {
+		for input, expected := range map[string]int{
+			"ne,ne,ne":       3,
+			"ne,ne,sw,sw":    0,
+			"ne,ne,s,s":      2,
+			"se,sw,se,sw,sw": 3,
+		} {
+			path, err := aocshared.ParseHexPath(input, aocshared.HexFlatTop)
+			assertTrue(t, err == nil, "path should parse: "+input, "parsed "+input)
+			assertEqual(t, origin.Walk(path).Distance(origin), expected, "distance after "+input)
+		}
+
+		_, err := aocshared.ParseHexPath("ne,e", aocshared.HexFlatTop)
+		assertTrue(t, err != nil, "e is not a flat-top direction", "e rejected")
+	})
+
+	// Test 2: Run-together pointy-top paths
+	t.Run("pointy_paths", func(t *testing.T) {
+		path, err := aocshared.ParseHexPath("esew", aocshared.HexPointyTop)
+		assertTrue(t, err == nil, "path should parse", "parsed esew")
+		assertEqual(t, len(path), 3, "e, se, w")
+		assertEqual(t, origin.Walk(path), origin.Neighbor(aocshared.HexSE), "esew ends south-east of the start")
+
+		path, _ = aocshared.ParseHexPath("nwwswee", aocshared.HexPointyTop)
+		assertEqual(t, origin.Walk(path), origin, "nwwswee comes back to the start")
+
+		_, err = aocshared.ParseHexPath("enx", aocshared.HexPointyTop)
+		assertTrue(t, err != nil, "unknown letters should fail", "bad path rejected")
+
+		assertEqual(t, aocshared.HexFlatN.Name(aocshared.HexFlatTop), "n", "flat-top name")
+		assertEqual(t, aocshared.HexFlatN.Name(aocshared.HexPointyTop), "nw", "same direction in pointy-top")
+	})
+
+	// Test 3: Rings, spirals and lines
+	t.Run("shapes", func(t *testing.T) {
+		center := aocshared.Hex{Q: 2, R: -1}
+		ring := center.Ring(3)
+		assertEqual(t, len(ring), 18, "ring size")
+		allAtRadius := true
+		for _, h := range ring {
+			allAtRadius = allAtRadius && h.Distance(center) == 3
+		}
+		assertTrue(t, allAtRadius, "every ring hexagon should be three steps away", "ring radius")
+		assertEqual(t, len(center.Spiral(2)), 19, "spiral size")
+		assertEqual(t, len(center.Ring(0)), 1, "ring of radius zero")
+		assertEqual(t, len(center.Ring(-1)), 0, "ring of negative radius")
+		assertEqual(t, len(center.Spiral(-1)), 0, "spiral of negative radius")
+
+		line := origin.LineTo(aocshared.Hex{Q: 4, R: -1})
+		assertEqual(t, len(line), 5, "line length")
+		assertEqual(t, line[4], aocshared.Hex{Q: 4, R: -1}, "line ends at the target")
+		connected := true
+		for i := 1; i < len(line); i++ {
+			connected = connected && line[i].Distance(line[i-1]) == 1
+		}
+		assertTrue(t, connected, "consecutive line hexagons should touch", "line connected")
+	})
+
+	// Test 4: Rotation and cube coordinates
+	t.Run("rotation", func(t *testing.T) {
+		east := origin.Neighbor(aocshared.HexE)
+		assertEqual(t, east.RotateCW(origin), origin.Neighbor(aocshared.HexSE), "east turns to south-east")
+		assertEqual(t, east.RotateCCW(origin), origin.Neighbor(aocshared.HexNE), "east turns to north-east")
+		assertEqual(t, aocshared.HexE.RotateCW(), aocshared.HexSE, "direction turns the same way")
+
+		h, center := aocshared.Hex{Q: 3, R: -5}, aocshared.Hex{Q: 1, R: 1}
+		turned := h
+		for range 6 {
+			turned = turned.RotateCW(center)
+			assertEqual(t, turned.Distance(center), h.Distance(center), "rotation keeps the distance")
+		}
+		assertEqual(t, turned, h, "six turns are the identity")
+
+		cube := h.Cube()
+		assertEqual(t, cube.Q+cube.R+cube.S, 0, "cube coordinates sum to zero")
+		assertEqual(t, cube.Axial(), h, "cube round trip")
+	})
+
+	// Test 5: Screen positions in both layouts
+	t.Run("pixels", func(t *testing.T) {
+		roundTrip := true
+		for _, layout := range []aocshared.HexLayout{aocshared.HexPointyTop, aocshared.HexFlatTop} {
+			for _, h := range origin.Spiral(3) {
+				x, y := aocshared.HexToPixel(h, layout, 10)
+				roundTrip = roundTrip && aocshared.PixelToHex(x+3, y-2, layout, 10) == h
+			}
+		}
+		assertTrue(t, roundTrip, "points near a centre should map back to its hexagon", "pixel round trip")
+	})
+
+	// Test 6: Text and PNG rendering
+	t.Run("render", func(t *testing.T) {
+		grid := aocshared.HexGridCreate(false)
+		for _, h := range origin.Spiral(1) {
+			grid.Set(h, h != origin)
+		}
+		glyph := func(wall bool) rune {
+			if wall {
+				return '#'
+			}
+			return 'o'
+		}
+		assertEqual(t, aocshared.HexGridString(grid, aocshared.HexPointyTop, glyph), " # #\n# o #\n # #\n", "pointy-top flower")
+		assertEqual(t, aocshared.HexGridString(grid, aocshared.HexFlatTop, glyph), "  #\n#   #\n  o\n#   #\n  #\n", "flat-top flower")
+		assertEqual(t, aocshared.HexGridCount(grid, func(wall bool) bool { return wall }), 6, "walls counted")
+
+		red := color.RGBA{R: 255, A: 255}
+		var buf bytes.Buffer
+		err := aocshared.HexGridEncodePNG(&buf, grid, aocshared.HexPointyTop, 10, func(wall bool) color.Color {
+			if wall {
+				return color.Black
+			}
+			return red
+		})
+		assertTrue(t, err == nil, "encoding should succeed", "encoded")
+
+		img, err := png.Decode(&buf)
+		assertTrue(t, err == nil, "PNG should decode", "decoded")
+		w, h := img.Bounds().Dx(), img.Bounds().Dy()
+		assertEqual(t, color.RGBAModel.Convert(img.At(w/2, h/2)), color.Color(red), "centre hexagon drawn")
+		_, _, _, alpha := img.At(0, 0).RGBA()
+		assertEqual(t, alpha, uint32(0), "corner outside every hexagon is transparent")
+	})
+}
@@ -0,0 +1,98 @@
+package tests
+
+import (
+	aocshared "aoc_shared"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testInputCrypto(t *testing.T) {
+	key := bytes.Repeat([]byte{0x42}, 32)
+	plaintext := []byte("L68\nR48\n")
+
+	// Test 1: Encrypting then decrypting gives the plaintext back
+	t.Run("round_trip", func(t *testing.T) {
+		sealed, err := aocshared.InputEncrypt(key, plaintext)
+		assertTrue(t, err == nil, "InputEncrypt should succeed", "InputEncrypt succeeded")
+		assertTrue(t, aocshared.InputIsEncrypted(sealed), "sealed input should be marked encrypted", "sealed input marked")
+		assertFalse(t, bytes.Contains(sealed, plaintext), "sealed input should not contain the plaintext", "plaintext hidden")
+
+		opened, err := aocshared.InputDecrypt(key, sealed)
+		assertTrue(t, err == nil, "InputDecrypt should succeed", "InputDecrypt succeeded")
+		assertEqual(t, string(opened), string(plaintext), "round trip")
+	})
+
+	// Test 2: A different key is rejected
+	t.Run("wrong_key", func(t *testing.T) {
+		sealed, _ := aocshared.InputEncrypt(key, plaintext)
+
+		_, err := aocshared.InputDecrypt(bytes.Repeat([]byte{0x24}, 32), sealed)
+		assertTrue(t, err != nil, "wrong key should fail", "wrong key rejected")
+	})
+
+	// Test 3: Truncated and tampered data are rejected
+	t.Run("corrupted", func(t *testing.T) {
+		sealed, _ := aocshared.InputEncrypt(key, plaintext)
+
+		_, err := aocshared.InputDecrypt(key, sealed[:len(sealed)-1])
+		assertTrue(t, err != nil, "truncated ciphertext should fail", "truncated ciphertext rejected")
+
+		_, err = aocshared.InputDecrypt(key, sealed[:len("AOCENC1\n")+4])
+		assertTrue(t, err != nil, "truncated nonce should fail", "truncated nonce rejected")
+
+		tampered := bytes.Clone(sealed)
+		tampered[len(tampered)-1] ^= 1
+		_, err = aocshared.InputDecrypt(key, tampered)
+		assertTrue(t, err != nil, "tampered ciphertext should fail", "tampered ciphertext rejected")
+	})
+
+	// Test 4: Data without the magic prefix is not decrypted
+	t.Run("missing_magic", func(t *testing.T) {
+		sealed, _ := aocshared.InputEncrypt(key, plaintext)
+
+		_, err := aocshared.InputDecrypt(key, sealed[1:])
+		assertTrue(t, err != nil, "missing magic should fail", "missing magic rejected")
+
+		_, err = aocshared.InputDecrypt(key, plaintext)
+		assertTrue(t, err != nil, "plaintext should not decrypt", "plaintext rejected")
+	})
+
+	// Test 5: Plaintext inputs are read as they are, without needing a key
+	t.Run("plaintext_passthrough", func(t *testing.T) {
+		t.Setenv(aocshared.InputKeyEnv, filepath.Join(t.TempDir(), "missing.key"))
+
+		path := filepath.Join(t.TempDir(), "input.txt")
+		if err := os.WriteFile(path, plaintext, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		content, err := aocshared.InputReadFile(path)
+		assertTrue(t, err == nil, "plaintext read should succeed", "plaintext read succeeded")
+		assertEqual(t, string(content), string(plaintext), "plaintext unchanged")
+	})
+
+	// Test 6: Encrypted inputs are decrypted with the configured key
+	t.Run("encrypted_read", func(t *testing.T) {
+		t.Setenv(aocshared.InputKeyEnv, filepath.Join(t.TempDir(), "input.key"))
+
+		keyPath, err := aocshared.InputKeyGenerate()
+		assertTrue(t, err == nil, "InputKeyGenerate should succeed", "key generated")
+		_, err = aocshared.InputKeyGenerate()
+		assertTrue(t, err != nil, "an existing key should not be overwritten", "existing key kept")
+
+		generated, err := aocshared.InputKeyLoad()
+		assertTrue(t, err == nil, "InputKeyLoad should succeed", "key loaded from "+keyPath)
+
+		sealed, _ := aocshared.InputEncrypt(generated, plaintext)
+		path := filepath.Join(t.TempDir(), "input.txt")
+		if err := os.WriteFile(path, sealed, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		content, err := aocshared.InputReadFile(path)
+		assertTrue(t, err == nil, "encrypted read should succeed", "encrypted read succeeded")
+		assertEqual(t, string(content), string(plaintext), "decrypted on read")
+	})
+}
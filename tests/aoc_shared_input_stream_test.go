@@ -0,0 +1,113 @@
+package tests
+
+import (
+	aocshared "aoc_shared"
+	"bufio"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// repeatingReader produces copies of one line without allocating per line.
+type repeatingReader struct {
+	line      string // including its line break
+	remaining int
+	offset    int
+}
+
+func newRepeatingReader(line string, count int) *repeatingReader {
+	return &repeatingReader{line: line + "\n", remaining: count}
+}
+
+func (r *repeatingReader) Read(p []byte) (int, error) {
+	if r.remaining == 0 {
+		return 0, io.EOF
+	}
+
+	n := copy(p, r.line[r.offset:])
+	r.offset += n
+	if r.offset == len(r.line) {
+		r.offset = 0
+		r.remaining--
+	}
+	return n, nil
+}
+
+func testInputStreaming(t *testing.T) {
+	// Test 1: Streamed lines match GetInput
+	t.Run("matches_get_input", func(t *testing.T) {
+		expected, err := aocshared.GetInput(2025, 1)
+		assertTrue(t, err == nil, "GetInput should succeed", "GetInput succeeded")
+
+		lines, errFn := aocshared.InputLines(2025, 1)
+		collected := make([]string, 0)
+		for line := range lines {
+			collected = append(collected, line)
+		}
+
+		assertTrue(t, errFn() == nil, "streaming should succeed", "streaming succeeded")
+		assertEqual(t, strings.Join(collected, "\n"), expected, "streamed content")
+	})
+
+	// Test 2: CRLF, inner blank lines and trailing blank lines
+	t.Run("line_rules", func(t *testing.T) {
+		lines, errFn := aocshared.ScanLines(strings.NewReader("a\r\n\r\nb  \n\n\n"))
+		collected := make([]string, 0)
+		for line := range lines {
+			collected = append(collected, line)
+		}
+
+		assertTrue(t, errFn() == nil, "scan should succeed", "scan succeeded")
+		assertEqual(t, len(collected), 3, "trailing blank lines dropped")
+		assertEqual(t, collected[1], "", "inner blank line kept")
+		assertEqual(t, collected[2], "b  ", "line whitespace kept")
+	})
+
+	// Test 3: Line numbers and early exit
+	t.Run("byte_lines", func(t *testing.T) {
+		lines, errFn := aocshared.ScanByteLines(strings.NewReader("x\ny\nz\n"))
+
+		lastNum := -1
+		for num, line := range lines {
+			lastNum = num
+			if string(line) == "y" {
+				break
+			}
+		}
+
+		assertTrue(t, errFn() == nil, "scan should succeed", "scan succeeded")
+		assertEqual(t, lastNum, 1, "stopped at the second line")
+	})
+
+	// Test 4: Memory stays bounded by the line, not the input
+	t.Run("bounded_memory", func(t *testing.T) {
+		const lineCount = 500_000
+		reader := newRepeatingReader(strings.Repeat("#.", 50), lineCount)
+
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+
+		lines, errFn := aocshared.ScanByteLines(reader)
+		count := 0
+		for range lines {
+			count++
+		}
+
+		runtime.ReadMemStats(&after)
+
+		assertTrue(t, errFn() == nil, "scan should succeed", "scan succeeded")
+		assertEqual(t, count, lineCount, "line count")
+		assertTrue(t, after.TotalAlloc-before.TotalAlloc < 1<<20, "allocations should not scale with the 50MB input", "allocations bounded")
+	})
+
+	// Test 5: Overlong lines surface as errors
+	t.Run("too_long", func(t *testing.T) {
+		reader := newRepeatingReader(strings.Repeat("a", aocshared.MaxStreamLineLength+1), 1)
+		lines, errFn := aocshared.ScanLines(reader)
+		for range lines {
+		}
+
+		assertEqual(t, errFn(), bufio.ErrTooLong, "overlong line error")
+	})
+}
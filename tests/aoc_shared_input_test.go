@@ -0,0 +1,58 @@
+package tests
+
+import (
+	aocshared "aoc_shared"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testInputResolution(t *testing.T) {
+	// Test 1: Root override and normalisation
+	t.Run("root_override", func(t *testing.T) {
+		root := t.TempDir()
+		dayDir := filepath.Join(root, "src", "2025", "day1")
+		if err := os.MkdirAll(dayDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dayDir, "input.txt"), []byte("  a\r\nb  \n\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Setenv(aocshared.RepoRootEnv, root)
+
+		dir, err := aocshared.PuzzleDir(2025, 1)
+		assertTrue(t, err == nil, "PuzzleDir should succeed", "PuzzleDir succeeded")
+		assertEqual(t, dir, dayDir, "puzzle dir under root")
+
+		raw, err := aocshared.GetInputRaw(2025, 1)
+		assertTrue(t, err == nil, "GetInputRaw should succeed", "GetInputRaw succeeded")
+		assertEqual(t, string(raw), "  a\r\nb  \n\n", "raw bytes preserved")
+
+		input, err := aocshared.GetInput(2025, 1)
+		assertTrue(t, err == nil, "GetInput should succeed", "GetInput succeeded")
+		assertEqual(t, input, "  a\nb  ", "normalised input keeps line whitespace")
+	})
+
+	// Test 2: Missing files are errors, not panics
+	t.Run("missing_input", func(t *testing.T) {
+		t.Setenv(aocshared.RepoRootEnv, t.TempDir())
+
+		_, err := aocshared.GetTestInputN(2025, 1, 7)
+		assertTrue(t, err != nil, "missing file should return an error", "missing file reported")
+	})
+
+	// Test 3: Resolution does not depend on the working directory
+	t.Run("any_working_directory", func(t *testing.T) {
+		t.Setenv(aocshared.RepoRootEnv, "")
+
+		expectedRoot, err := aocshared.RepoRoot()
+		assertTrue(t, err == nil, "RepoRoot should succeed from the repo", "RepoRoot found")
+
+		t.Chdir(t.TempDir())
+
+		root, err := aocshared.RepoRoot()
+		assertTrue(t, err == nil, "RepoRoot should succeed outside the repo", "RepoRoot found outside repo")
+		assertEqual(t, root, expectedRoot, "same root from any directory")
+	})
+}
@@ -0,0 +1,80 @@
+package tests
+
+import (
+	aocshared "aoc_shared"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func testInputParsing(t *testing.T) {
+	// Test 1: Lines and blocks
+	t.Run("lines_and_blocks", func(t *testing.T) {
+		input := "  a  \n\n b\r\n\n\n0:\n##.\n\n1x2: 3"
+
+		lines := aocshared.ParseLines(input)
+		assertEqual(t, len(lines), 5, "non-empty line count")
+		assertEqual(t, lines[0], "a", "lines are trimmed")
+		assertEqual(t, lines[1], "b", "CRLF handled")
+
+		blocks := aocshared.ParseBlocks(input)
+		assertEqual(t, len(blocks), 4, "block count")
+		assertEqual(t, blocks[2][1], "##.", "block rows kept")
+		assertEqual(t, blocks[0][0], "  a  ", "block lines untrimmed")
+
+		_, startLines := aocshared.ParseBlocksNumbered(input)
+		assertEqual(t, fmt.Sprint(startLines), "[1 3 6 9]", "block start lines")
+	})
+
+	// Test 2: Signed integers
+	t.Run("ints", func(t *testing.T) {
+		values, err := aocshared.ParseInts("p=-3,12 v=10-20 x-5")
+		assertTrue(t, err == nil, "ParseInts should succeed", "ParseInts succeeded")
+		assertEqual(t, len(values), 5, "int count")
+		assertEqual(t, values[0], -3, "negative int")
+		assertEqual(t, values[2], 10, "range start")
+		assertEqual(t, values[3], 20, "dash after digit is not a sign")
+		assertEqual(t, values[4], -5, "dash after letter is a sign")
+
+		_, err = aocshared.ParseIntLines("1 2\n\n  3 99999999999999999999")
+		var parseErr *aocshared.ParseError
+		assertTrue(t, errors.As(err, &parseErr), "overflow should be a ParseError", "overflow reported")
+		assertEqual(t, parseErr.Line, 3, "overflow line")
+		assertEqual(t, parseErr.Column, 5, "overflow column")
+
+		assertTrue(t, errors.As(aocshared.ParseErrorInBlock(err, 10), &parseErr), "shifted error should stay a ParseError", "shifted error kept")
+		assertEqual(t, parseErr.Line, 12, "line moved to the block start")
+	})
+
+	// Test 3: Ranges
+	t.Run("ranges", func(t *testing.T) {
+		ranges, err := aocshared.ParseRanges("11-22, 95-115\n-5--2")
+		assertTrue(t, err == nil, "ParseRanges should succeed", "ParseRanges succeeded")
+		assertEqual(t, len(ranges), 3, "range count")
+		assertEqual(t, ranges[1], aocshared.Range{Start: 95, End: 115}, "second range")
+		assertEqual(t, ranges[2], aocshared.Range{Start: -5, End: -2}, "negative range")
+
+		_, err = aocshared.ParseRanges("1-2,3-x")
+		var parseErr *aocshared.ParseError
+		assertTrue(t, errors.As(err, &parseErr), "bad range should be a ParseError", "bad range reported")
+		assertEqual(t, parseErr.Line, 1, "bad range line")
+		assertEqual(t, parseErr.Column, 7, "bad range column")
+	})
+
+	// Test 4: Coordinate tuples
+	t.Run("tuples", func(t *testing.T) {
+		tuples, err := aocshared.ParseTuples("162,817,812\n57,-618,57\n", 3)
+		assertTrue(t, err == nil, "ParseTuples should succeed", "ParseTuples succeeded")
+		assertEqual(t, len(tuples), 2, "tuple count")
+		assertEqual(t, tuples[1][1], -618, "tuple value")
+
+		_, err = aocshared.ParseTuples("1,2,3\n4,five,6", 3)
+		var parseErr *aocshared.ParseError
+		assertTrue(t, errors.As(err, &parseErr), "bad tuple should be a ParseError", "bad tuple reported")
+		assertEqual(t, parseErr.Line, 2, "bad tuple line")
+		assertEqual(t, parseErr.Column, 3, "bad tuple column")
+
+		_, err = aocshared.ParseTuple("1,2", 3)
+		assertTrue(t, err != nil, "arity mismatch should fail", "arity checked")
+	})
+}
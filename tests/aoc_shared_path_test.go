@@ -0,0 +1,154 @@
+package tests
+
+import (
+	aocshared "aoc_shared"
+	"iter"
+	"testing"
+)
+
+func testPathfinding(t *testing.T) {
+	maze, markers, err := aocshared.GridParse(
+		"S.#.....\n"+
+			".##.###.\n"+
+			"....#..E\n"+
+			".##...#.",
+		map[rune]rune{'.': '.', '#': '#', 'S': '.', 'E': '.'}, 'S', 'E')
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start, end := markers['S'][0], markers['E'][0]
+	isEnd := func(p aocshared.Point) bool { return p == end }
+	open := aocshared.GridPathOptions[rune]{
+		Conn:     aocshared.Conn4,
+		Passable: func(from, to aocshared.Point, cell rune) bool { return cell != '#' },
+	}
+
+	// Test 1: BFS shortest path and its reconstruction
+	t.Run("bfs", func(t *testing.T) {
+		result := aocshared.GridBFS(maze, []aocshared.Point{start}, isEnd, open)
+		assertTrue(t, result.Found, "end should be reachable", "end reached")
+		assertEqual(t, result.Distance, 11, "shortest distance")
+		assertEqual(t, len(result.Path), 12, "path includes both ends")
+		assertEqual(t, result.Path[0], start, "path starts at the source")
+		assertEqual(t, result.Path[len(result.Path)-1], end, "path ends at the target")
+
+		for i := 1; i < len(result.Path); i++ {
+			assertEqual(t, aocshared.ManhattanDistance(result.Path[i-1], result.Path[i]), 1, "path steps are adjacent")
+		}
+	})
+
+	// Test 2: Exhaustive search gives a distance field
+	t.Run("distance_field", func(t *testing.T) {
+		result := aocshared.GridBFS(maze, []aocshared.Point{start}, nil, open)
+		assertFalse(t, result.Found, "no target means nothing is found", "nothing found")
+
+		field := aocshared.GridDistanceField(maze, result)
+		dist, _ := field.At(end.X, end.Y)
+		assertEqual(t, dist, 11, "field distance to the end")
+		wall, _ := field.At(2, 0)
+		assertEqual(t, wall, -1, "walls are unreached")
+	})
+
+	// Test 3: Multiple sources
+	t.Run("multi_source", func(t *testing.T) {
+		sources := []aocshared.Point{start, {X: 7, Y: 0}}
+		result := aocshared.GridBFS(maze, sources, isEnd, open)
+		assertEqual(t, result.Distance, 2, "nearest source wins")
+		assertEqual(t, result.Path[0], aocshared.Point{X: 7, Y: 0}, "path starts at the nearest source")
+	})
+
+	// Test 4: Dijkstra and A* with step costs agree
+	t.Run("weighted", func(t *testing.T) {
+		weighted := open
+		weighted.Cost = func(from, to aocshared.Point, cell rune) int {
+			if to.Y == 2 {
+				return 5
+			}
+			return 1
+		}
+
+		dijkstra := aocshared.GridDijkstra(maze, []aocshared.Point{start}, isEnd, weighted)
+		heuristic := func(p aocshared.Point) int { return aocshared.ManhattanDistance(p, end) }
+		astar := aocshared.GridAStar(maze, []aocshared.Point{start}, isEnd, heuristic, weighted)
+
+		assertEqual(t, dijkstra.Distance, 33, "weighted route goes over the top")
+		assertEqual(t, astar.Distance, dijkstra.Distance, "A* matches Dijkstra")
+		assertTrue(t, len(astar.Dist) <= len(dijkstra.Dist), "A* should not explore more than Dijkstra", "A* explored less")
+	})
+
+	// Test 5: Unreachable target
+	t.Run("unreachable", func(t *testing.T) {
+		result := aocshared.GridDijkstra(maze, []aocshared.Point{start}, func(p aocshared.Point) bool { return p.X == 2 && p.Y == 0 }, open)
+		assertFalse(t, result.Found, "a wall cannot be reached", "wall unreachable")
+		assertEqual(t, result.Distance, -1, "no distance")
+		assertTrue(t, result.Path == nil, "no path should be returned", "no path")
+	})
+
+	// Test 6: States carrying a direction, where turning costs extra
+	t.Run("state_extension", func(t *testing.T) {
+		type heading struct {
+			Pos aocshared.Point
+			Dir aocshared.Direction
+		}
+
+		next := func(s heading) iter.Seq2[heading, int] {
+			return func(yield func(heading, int) bool) {
+				x, y := s.Dir.ApplyDelta(s.Pos.X, s.Pos.Y)
+				if cell, err := maze.At(x, y); err == nil && cell != '#' {
+					if !yield(heading{Pos: aocshared.Point{X: x, Y: y}, Dir: s.Dir}, 1) {
+						return
+					}
+				}
+				if !yield(heading{Pos: s.Pos, Dir: s.Dir.RotateCW().RotateCW()}, 1000) {
+					return
+				}
+				yield(heading{Pos: s.Pos, Dir: s.Dir.RotateCCW().RotateCCW()}, 1000)
+			}
+		}
+
+		result := aocshared.PathDijkstra([]heading{{Pos: start, Dir: aocshared.E}}, next, func(s heading) bool { return s.Pos == end })
+		assertTrue(t, result.Found, "end should be reachable", "end reached")
+		// Over the top is two steps longer but saves a turn.
+		assertEqual(t, result.Distance/1000, 5, "fewest turns")
+		assertEqual(t, result.Distance%1000, 13, "steps along the route with fewest turns")
+	})
+
+	// Test 7: Wrapping grids, views and sparse grids
+	t.Run("other_grids", func(t *testing.T) {
+		wrapped := aocshared.GridClone(maze)
+		wrapped.SetWrap(aocshared.WrapX)
+		result := aocshared.GridBFS(wrapped, []aocshared.Point{start}, nil, open)
+		field := aocshared.GridDistanceField(wrapped, result)
+		dist, _ := field.At(end.X, end.Y)
+		assertEqual(t, dist, 3, "wrapping around the left edge")
+		assertEqual(t, field.Wrap(), aocshared.WrapX, "field keeps the wrap mode")
+
+		view, err := aocshared.GridViewCreate(maze, aocshared.Point{}, 4, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result = aocshared.GridBFS(view, []aocshared.Point{start}, func(p aocshared.Point) bool { return p == aocshared.Point{X: 3, Y: 0} }, open)
+		assertEqual(t, result.Distance, 7, "path inside the view")
+		assertEqual(t, aocshared.GridDistanceField(view, result).Width(), 4, "field covers the view")
+
+		// A wall across the x axis, with the search kept to a 7x7 box around the origin.
+		sparse := aocshared.SparseGridCreate('.')
+		for y := -1; y <= 1; y++ {
+			sparse.Set(0, y, '#')
+		}
+		sparse.Set(-2, 0, 'S')
+		sparse.Set(2, 0, 'E')
+		boxed := open
+		boxed.Passable = func(from, to aocshared.Point, cell rune) bool {
+			return cell != '#' && max(to.X, -to.X) <= 3 && max(to.Y, -to.Y) <= 3
+		}
+		from, to := aocshared.Point{X: -2, Y: 0}, aocshared.Point{X: 2, Y: 0}
+		result = aocshared.GridBFS(sparse, []aocshared.Point{from}, func(p aocshared.Point) bool { return p == to }, boxed)
+		assertEqual(t, result.Distance, 8, "path around the wall")
+
+		field = aocshared.GridDistanceField(sparse, result)
+		dist, _ = field.At(4, 1)
+		assertEqual(t, dist, 8, "field is offset by the sparse bounds")
+	})
+}
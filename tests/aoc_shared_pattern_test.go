@@ -0,0 +1,93 @@
+package tests
+
+import (
+	aocshared "aoc_shared"
+	"errors"
+	"testing"
+)
+
+type patternButton struct {
+	Lights []int
+}
+
+type patternMachine struct {
+	Diagram string
+	Buttons []patternButton `pattern:"{Lights}"`
+	Joltage []int
+}
+
+const patternMachineFormat = `\[{Diagram}\] {Buttons:(...)+} \{{Joltage:ints}\}`
+
+func testLineDecoding(t *testing.T) {
+	// Test 1: Scalars and whitespace-separated lists
+	t.Run("scalars", func(t *testing.T) {
+		type region struct {
+			Width  int
+			Height uint8
+			Counts []int
+		}
+
+		regions := make([]region, 0)
+		err := aocshared.Unmarshal([]string{"12x5: 1 0 1 0 2 2", "4x4:  0 0"}, &regions, "{Width}x{Height}: {Counts}")
+		assertTrue(t, err == nil, "Unmarshal should succeed", "Unmarshal succeeded")
+		assertEqual(t, len(regions), 2, "region count")
+		assertEqual(t, regions[0].Width, 12, "width")
+		assertEqual(t, regions[0].Height, uint8(5), "height")
+		assertEqual(t, len(regions[0].Counts), 6, "count list length")
+		assertEqual(t, regions[0].Counts[4], 2, "count value")
+		assertEqual(t, len(regions[1].Counts), 2, "whitespace runs collapse")
+	})
+
+	// Test 2: Repeated items decoded into nested structs
+	t.Run("repeated_nested", func(t *testing.T) {
+		machines := make([]patternMachine, 0)
+		err := aocshared.Unmarshal([]string{"[.##.] (3) (1,3) (2) {3,5,4,7}"}, &machines, patternMachineFormat)
+		assertTrue(t, err == nil, "Unmarshal should succeed", "Unmarshal succeeded")
+		assertEqual(t, machines[0].Diagram, ".##.", "diagram")
+		assertEqual(t, len(machines[0].Buttons), 3, "button count")
+		assertEqual(t, len(machines[0].Buttons[1].Lights), 2, "comma list length")
+		assertEqual(t, machines[0].Buttons[1].Lights[1], 3, "comma list value")
+		assertEqual(t, len(machines[0].Joltage), 4, "joltage count")
+		assertEqual(t, machines[0].Joltage[3], 7, "joltage value")
+	})
+
+	// Test 3: Single line
+	t.Run("single_line", func(t *testing.T) {
+		var point struct{ X, Y int }
+		err := aocshared.UnmarshalLine("p=-4,7", &point, "p={X},{Y}")
+		assertTrue(t, err == nil, "UnmarshalLine should succeed", "UnmarshalLine succeeded")
+		assertEqual(t, point.X, -4, "x")
+		assertEqual(t, point.Y, 7, "y")
+	})
+
+	// Test 4: Structural mismatch points at the missing literal
+	t.Run("mismatch", func(t *testing.T) {
+		machines := make([]patternMachine, 0)
+		err := aocshared.Unmarshal([]string{"[#] (0) {1}", "[#] (0) 1}"}, &machines, patternMachineFormat)
+
+		var parseErr *aocshared.ParseError
+		assertTrue(t, errors.As(err, &parseErr), "mismatch should be a ParseError", "mismatch reported")
+		assertEqual(t, parseErr.Line, 2, "mismatch line")
+		assertEqual(t, parseErr.Column, 8, "mismatch column")
+		assertEqual(t, parseErr.Msg, `expected " {"`, "mismatch message")
+	})
+
+	// Test 5: Conversion errors name the field and point into the capture
+	t.Run("conversion", func(t *testing.T) {
+		machines := make([]patternMachine, 0)
+		err := aocshared.Unmarshal([]string{"[#] (0) (1,x) {1}"}, &machines, patternMachineFormat)
+
+		var parseErr *aocshared.ParseError
+		assertTrue(t, errors.As(err, &parseErr), "bad item should be a ParseError", "bad item reported")
+		assertEqual(t, parseErr.Line, 1, "bad item line")
+		assertEqual(t, parseErr.Column, 12, "bad item column")
+		assertEqual(t, parseErr.Msg, `field Buttons: field Lights: invalid int "x"`, "bad item message")
+	})
+
+	// Test 6: Unknown fields are rejected
+	t.Run("unknown_field", func(t *testing.T) {
+		var point struct{ X int }
+		err := aocshared.UnmarshalLine("1,2", &point, "{X},{Z}")
+		assertTrue(t, err != nil, "unknown field should fail", "unknown field rejected")
+	})
+}
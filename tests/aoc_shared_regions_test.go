@@ -0,0 +1,112 @@
+package tests
+
+import (
+	aocshared "aoc_shared"
+	"testing"
+)
+
+func testRegions(t *testing.T) {
+	garden := func(t *testing.T, input string) *aocshared.Grid[rune] {
+		grid, _, err := aocshared.GridParseFunc(input, func(char rune) (rune, error) { return char, nil })
+		if err != nil {
+			t.Fatal(err)
+		}
+		return grid
+	}
+	equal := func(a, b rune) bool { return a == b }
+
+	fencePrices := func(regions []aocshared.Region[rune]) (byPerimeter, bySides int) {
+		for _, region := range regions {
+			byPerimeter += region.Area * region.Perimeter
+			bySides += region.Area * region.Sides
+		}
+		return byPerimeter, bySides
+	}
+
+	// Test 1: Labelling and per-region measurements
+	t.Run("label", func(t *testing.T) {
+		labels, regions := aocshared.GridLabelComponents(garden(t, "AAAA\nBBCD\nBBCC\nEEEC"), aocshared.Conn4, equal)
+		assertEqual(t, len(regions), 5, "region count")
+
+		label, _ := labels.At(3, 2)
+		c := regions[label]
+		assertEqual(t, c.Cell, 'C', "region cell")
+		assertEqual(t, c.Area, 4, "area")
+		assertEqual(t, c.Perimeter, 10, "perimeter")
+		assertEqual(t, c.Sides, 8, "sides")
+		assertEqual(t, c.Corners, 8, "corners")
+		assertEqual(t, c.Min, aocshared.Point{X: 2, Y: 1}, "bounding box min")
+		assertEqual(t, c.Max, aocshared.Point{X: 3, Y: 3}, "bounding box max")
+
+		byPerimeter, bySides := fencePrices(regions)
+		assertEqual(t, byPerimeter, 140, "perimeter price")
+		assertEqual(t, bySides, 80, "sides price")
+	})
+
+	// Test 2: Regions with holes
+	t.Run("holes", func(t *testing.T) {
+		_, regions := aocshared.GridLabelComponents(garden(t, "OOOOO\nOXOXO\nOOOOO\nOXOXO\nOOOOO"), aocshared.Conn4, equal)
+		assertEqual(t, len(regions), 5, "outer region and four holes")
+		assertEqual(t, regions[0].Perimeter, 36, "holes add to the perimeter")
+		assertEqual(t, regions[0].Sides, 20, "holes add sides")
+
+		byPerimeter, bySides := fencePrices(regions)
+		assertEqual(t, byPerimeter, 772, "perimeter price")
+		assertEqual(t, bySides, 436, "sides price")
+
+		_, regions = aocshared.GridLabelComponents(garden(t, "EEEEE\nEXXXX\nEEEEE\nEXXXX\nEEEEE"), aocshared.Conn4, equal)
+		_, bySides = fencePrices(regions)
+		assertEqual(t, bySides, 236, "sides price of the E shape")
+	})
+
+	// Test 3: Diagonal connectivity
+	t.Run("conn8", func(t *testing.T) {
+		grid := garden(t, "#.\n.#")
+		_, regions4 := aocshared.GridLabelComponents(grid, aocshared.Conn4, equal)
+		_, regions8 := aocshared.GridLabelComponents(grid, aocshared.Conn8, equal)
+		assertEqual(t, len(regions4), 4, "diagonal cells are separate under Conn4")
+		assertEqual(t, len(regions8), 2, "diagonal cells join under Conn8")
+		assertEqual(t, regions8[0].Area, 2, "joined area")
+		assertEqual(t, regions8[0].Sides, 8, "diagonal cells share no side")
+	})
+
+	// Test 4: Flood fill from a point
+	t.Run("flood_fill", func(t *testing.T) {
+		grid := garden(t, "AAAA\nBBCD\nBBCC\nEEEC")
+		filled := aocshared.GridFloodFill(grid, aocshared.Point{X: 2, Y: 1}, aocshared.Conn4, equal)
+		assertEqual(t, len(filled), 4, "filled cells")
+		assertEqual(t, filled[0], aocshared.Point{X: 2, Y: 1}, "fill starts at the seed")
+
+		assertTrue(t, aocshared.GridFloodFill(grid, aocshared.Point{X: 9, Y: 9}, aocshared.Conn4, equal) == nil, "outside seed should fill nothing", "outside seed fills nothing")
+	})
+
+	// Test 5: Views and sparse grids
+	t.Run("other_grids", func(t *testing.T) {
+		view, err := aocshared.GridViewCreate(garden(t, "AAAA\nBBCD\nBBCC\nEEEC"), aocshared.Point{X: 1, Y: 1}, 3, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, regions := aocshared.GridLabelComponents(view, aocshared.Conn4, equal)
+		assertEqual(t, len(regions), 3, "regions inside the view")
+		assertEqual(t, regions[1].Area, 3, "C region cut by the view")
+
+		// AA..
+		// ....
+		// ...A
+		sparse := aocshared.SparseGridCreate('.')
+		sparse.Set(-1, -1, 'A')
+		sparse.Set(0, -1, 'A')
+		sparse.Set(2, 1, 'A')
+
+		labels, regions := aocshared.GridLabelComponents(sparse, aocshared.Conn4, equal)
+		assertEqual(t, len(regions), 3, "sparse region count")
+		assertEqual(t, regions[0].Min, aocshared.Point{X: -1, Y: -1}, "regions use the sparse coordinates")
+		assertEqual(t, regions[0].Max, aocshared.Point{X: 0, Y: -1}, "sparse region extent")
+		assertEqual(t, regions[1].Area, 9, "unset cells inside the bounds form a region")
+		label, _ := labels.At(3, 2)
+		assertEqual(t, label, 2, "labels are offset by the sparse bounds")
+
+		filled := aocshared.GridFloodFill(sparse, aocshared.Point{X: 2, Y: 0}, aocshared.Conn4, equal)
+		assertEqual(t, len(filled), 9, "fill stays inside the bounds")
+	})
+}
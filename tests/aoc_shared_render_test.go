@@ -0,0 +1,106 @@
+package tests
+
+import (
+	aocshared "aoc_shared"
+	"bytes"
+	"image/color"
+	"os"
+	"strings"
+	"testing"
+)
+
+func testGridRendering(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+	style := func(wall bool) aocshared.CellStyle {
+		if wall {
+			return aocshared.CellStyle{Glyph: '#', FG: red}
+		}
+		return aocshared.CellStyle{Glyph: '.'}
+	}
+	grid := aocshared.GridCreate([][]bool{
+		{true, false, false, true},
+		{false, false, true, false},
+		{true, true, false, false},
+	})
+
+	// Test 1: Monochrome output is just the glyphs
+	t.Run("monochrome", func(t *testing.T) {
+		renderer := aocshared.GridRendererCreate(style)
+		aocshared.GridRendererSetColor(renderer, false)
+
+		var buf bytes.Buffer
+		err := aocshared.GridRender(&buf, renderer, grid)
+		assertTrue(t, err == nil, "render should succeed", "rendered")
+		assertEqual(t, buf.String(), "#..#\n..#.\n##..\n", "monochrome grid")
+	})
+
+	// Test 2: Colours are only switched when they change, and every line is reset
+	t.Run("colour", func(t *testing.T) {
+		renderer := aocshared.GridRendererCreate(style)
+		aocshared.GridRendererSetColor(renderer, true)
+
+		var buf bytes.Buffer
+		aocshared.GridRender(&buf, renderer, grid)
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+
+		redFG := "\x1b[0m\x1b[38;2;255;0;0m"
+		assertEqual(t, lines[0], redFG+"#\x1b[0m.."+redFG+"#\x1b[0m", "first row escapes")
+		assertEqual(t, lines[2], redFG+"##\x1b[0m..\x1b[0m", "repeated colour written once")
+	})
+
+	// Test 3: Overlays replace only the parts they set, later ones on top
+	t.Run("overlays", func(t *testing.T) {
+		renderer := aocshared.GridRendererCreate(style)
+		aocshared.GridRendererSetColor(renderer, false)
+
+		path := []aocshared.Point{{X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}}
+		aocshared.GridRendererAddOverlay(renderer, aocshared.OverlayPoints(path, aocshared.CellStyle{Glyph: 'o'}))
+		aocshared.GridRendererAddOverlay(renderer, aocshared.OverlayPoints(path[2:], aocshared.CellStyle{Glyph: 'S'}))
+		aocshared.GridRendererAddOverlay(renderer, aocshared.OverlayWhere(grid, func(wall bool) bool { return wall }, aocshared.CellStyle{BG: blue}))
+
+		var buf bytes.Buffer
+		aocshared.GridRender(&buf, renderer, grid)
+		assertEqual(t, buf.String(), "#o.#\nSo#.\n##..\n", "path drawn over the grid")
+
+		aocshared.GridRendererSetColor(renderer, true)
+		buf.Reset()
+		aocshared.GridRender(&buf, renderer, grid)
+		assertTrue(t, strings.HasPrefix(buf.String(), "\x1b[0m\x1b[38;2;255;0;0m\x1b[48;2;0;0;255m#"), "background overlay kept the foreground", "foreground and background combined")
+	})
+
+	// Test 4: Viewport crops, including sparse grids with negative coordinates
+	t.Run("viewport", func(t *testing.T) {
+		renderer := aocshared.GridRendererCreate(style)
+		aocshared.GridRendererSetColor(renderer, false)
+		aocshared.GridRendererSetViewport(renderer, aocshared.Point{X: 1, Y: 1}, 2, 5)
+
+		var buf bytes.Buffer
+		aocshared.GridRender(&buf, renderer, grid)
+		assertEqual(t, buf.String(), ".#\n#.\n", "viewport clipped to the grid")
+
+		sparse := aocshared.SparseGridCreate(false)
+		sparse.Set(-5, -5, true)
+		sparse.Set(2, -1, true)
+		sparse.Set(5, 5, true)
+		aocshared.GridRendererSetViewport(renderer, aocshared.Point{}, 3, 3)
+		aocshared.GridRendererCenterOn(renderer, aocshared.Point{X: 2, Y: -1})
+
+		buf.Reset()
+		aocshared.GridRender(&buf, renderer, sparse)
+		assertEqual(t, buf.String(), "...\n.#.\n...\n", "viewport centred on a point")
+	})
+
+	// Test 5: NO_COLOR disables colour regardless of the terminal
+	t.Run("no_color", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "1")
+		assertFalse(t, aocshared.ColorEnabled(os.Stdout), "NO_COLOR should disable colour", "colour disabled")
+
+		file, err := os.CreateTemp(t.TempDir(), "out")
+		assertTrue(t, err == nil, "temp file should be created", "created")
+		defer file.Close()
+
+		t.Setenv("NO_COLOR", "")
+		assertFalse(t, aocshared.ColorEnabled(file), "a plain file is not a terminal", "no colour for files")
+	})
+}
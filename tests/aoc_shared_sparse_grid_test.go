@@ -0,0 +1,97 @@
+package tests
+
+import (
+	aocshared "aoc_shared"
+	"testing"
+)
+
+func testSparseGrid(t *testing.T) {
+	// Test 1: Unset cells, negative coordinates and growing bounds
+	t.Run("get_set_bounds", func(t *testing.T) {
+		grid := aocshared.SparseGridCreate('.')
+
+		cell, err := grid.At(1000, -1000)
+		assertTrue(t, err == nil, "At should never fail", "At succeeded")
+		assertEqual(t, cell, '.', "unset cell is the fill value")
+
+		_, maxPoint := grid.Bounds()
+		assertEqual(t, maxPoint, aocshared.Point{X: -1, Y: -1}, "empty bounds")
+		assertFalse(t, grid.InBounds(0, 0), "empty grid should contain nothing", "empty grid contains nothing")
+
+		grid.Set(-3, 2, '#')
+		grid.Set(4, -1, '#')
+		minPoint, maxPoint := grid.Bounds()
+		assertEqual(t, minPoint, aocshared.Point{X: -3, Y: -1}, "min grows")
+		assertEqual(t, maxPoint, aocshared.Point{X: 4, Y: 2}, "max grows")
+		assertTrue(t, grid.InBounds(0, 0), "origin should be inside", "origin inside")
+		assertEqual(t, grid.Len(), 2, "stored cells")
+	})
+
+	// Test 2: Deleting an edge cell shrinks the bounds
+	t.Run("delete", func(t *testing.T) {
+		grid := aocshared.SparseGridCreate(0)
+		grid.Set(0, 0, 1)
+		grid.Set(5, 5, 1)
+		grid.Delete(5, 5)
+
+		_, maxPoint := grid.Bounds()
+		assertEqual(t, maxPoint, aocshared.Point{X: 0, Y: 0}, "bounds shrink")
+		assertFalse(t, grid.Has(5, 5), "deleted cell should be gone", "deleted cell gone")
+
+		grid.Set(-2, 3, 1)
+		minPoint, maxPoint := grid.Bounds()
+		assertEqual(t, minPoint, aocshared.Point{X: -2, Y: 0}, "min grows after a deletion")
+		assertEqual(t, maxPoint, aocshared.Point{X: 0, Y: 3}, "max grows after a deletion")
+
+		grid.Delete(0, 0)
+		grid.Delete(-2, 3)
+		_, maxPoint = grid.Bounds()
+		assertEqual(t, maxPoint, aocshared.Point{X: -1, Y: -1}, "bounds of an emptied grid")
+		grid.Set(7, 7, 1)
+		minPoint, maxPoint = grid.Bounds()
+		assertEqual(t, minPoint, maxPoint, "single cell after emptying")
+	})
+
+	// Test 3: Iteration in reading order and unbounded neighbours
+	t.Run("iteration", func(t *testing.T) {
+		grid := aocshared.SparseGridCreate(0)
+		grid.Set(2, 1, 3)
+		grid.Set(-1, 1, 2)
+		grid.Set(5, -4, 1)
+
+		order := make([]int, 0)
+		for _, cell := range grid.All() {
+			order = append(order, cell)
+		}
+		assertEqual(t, len(order), 3, "stored cells visited")
+		assertEqual(t, order[0]*100+order[1]*10+order[2], 123, "reading order")
+
+		neighbours := 0
+		for range grid.Neighbors(aocshared.Point{X: -50, Y: -50}, aocshared.Conn8) {
+			neighbours++
+		}
+		assertEqual(t, neighbours, 8, "no edges")
+	})
+
+	// Test 4: Helpers accept either representation
+	t.Run("shared_helpers", func(t *testing.T) {
+		dense := aocshared.GridCreate([][]int{{1, 0}, {1, 1}})
+		sparse := aocshared.SparseGridCreate(0)
+		for p, cell := range dense.All() {
+			if cell != 0 {
+				sparse.Set(p.X, p.Y, cell)
+			}
+		}
+
+		isSet := func(cell int) bool { return cell == 1 }
+		grids := []aocshared.CellGrid[int]{dense, sparse}
+		for _, grid := range grids {
+			assertEqual(t, aocshared.GridCount(grid, isSet), 3, "count")
+			assertEqual(t, grid.Adjacencies(1, 0), [8]int{0, 0, 0, 0, 1, 1, 1, 0}, "adjacencies")
+		}
+
+		clone := aocshared.SparseGridClone(sparse)
+		clone.Set(9, 9, 1)
+		assertEqual(t, sparse.Len(), 3, "clone is independent")
+	})
+}
@@ -0,0 +1,67 @@
+package tests
+
+import (
+	aocshared "aoc_shared"
+	"testing"
+)
+
+func testTextBlock(t *testing.T) {
+	const worksheet = "123 328  51 64 \n 45 64  387 23 \n  6 98  215 314\n*   +   *   +  \n\n"
+
+	// Test 1: Ragged lines are padded into a rectangle
+	t.Run("padding", func(t *testing.T) {
+		block := aocshared.TextBlockCreate("ab\na\r\nabc\n\n")
+		width, height := aocshared.TextBlockSize(block)
+		assertEqual(t, width, 3, "width of the longest line")
+		assertEqual(t, height, 3, "trailing blank lines dropped")
+		row, _ := aocshared.TextBlockRow(block, 1)
+		assertEqual(t, row, "a  ", "short row padded")
+
+		_, err := aocshared.TextBlockAt(block, 3, 0)
+		assertTrue(t, err != nil, "out of range column should fail", "out of range rejected")
+		_, err = aocshared.TextBlockRow(block, 3)
+		assertTrue(t, err != nil, "out of range row should fail", "out of range row rejected")
+		_, err = aocshared.TextBlockCol(block, -1)
+		assertTrue(t, err != nil, "out of range column should fail", "out of range column rejected")
+	})
+
+	// Test 2: Columns read top to bottom
+	t.Run("columns", func(t *testing.T) {
+		block := aocshared.TextBlockCreate(worksheet)
+		col, _ := aocshared.TextBlockCol(block, 2)
+		assertEqual(t, col, "356 ", "column string")
+		assertFalse(t, aocshared.TextBlockIsBlankCol(block, 99), "columns outside the block should not be blank", "outside column not blank")
+		assertTrue(t, aocshared.TextBlockIsBlankCol(block, 3), "separator column should be blank", "separator detected")
+		assertFalse(t, aocshared.TextBlockIsBlankCol(block, 0), "operator column should not be blank", "operator column kept")
+	})
+
+	// Test 3: Row and column slicing
+	t.Run("slicing", func(t *testing.T) {
+		block := aocshared.TextBlockCreate(worksheet)
+		numbers, err := aocshared.TextBlockRows(block, 0, 3)
+		assertTrue(t, err == nil, "row slice should succeed", "row slice succeeded")
+		_, height := aocshared.TextBlockSize(numbers)
+		assertEqual(t, height, 3, "row slice height")
+
+		right, err := aocshared.TextBlockCols(numbers, 12, 15)
+		assertTrue(t, err == nil, "column slice should succeed", "column slice succeeded")
+		assertEqual(t, aocshared.TextBlockString(right), "64 \n23 \n314", "column slice")
+
+		_, err = aocshared.TextBlockRows(block, 2, 5)
+		assertTrue(t, err != nil, "row slice past the end should fail", "row slice past the end rejected")
+		_, err = aocshared.TextBlockCols(block, 3, 2)
+		assertTrue(t, err != nil, "reversed column slice should fail", "reversed column slice rejected")
+	})
+
+	// Test 4: Panels between all-space columns
+	t.Run("panels", func(t *testing.T) {
+		panels := aocshared.TextBlockPanels(aocshared.TextBlockCreate(worksheet))
+		assertEqual(t, len(panels), 4, "panel count")
+		assertEqual(t, aocshared.TextBlockString(panels[1]), "328\n64 \n98 \n+  ", "second panel")
+		first, _ := aocshared.TextBlockCol(panels[3], 0)
+		assertEqual(t, first, "623+", "last panel first column")
+
+		empty := aocshared.TextBlockPanels(aocshared.TextBlockCreate("   \n "))
+		assertEqual(t, len(empty), 0, "blank block has no panels")
+	})
+}
@@ -0,0 +1,122 @@
+package tests
+
+import (
+	aocshared "aoc_shared"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// conwayCubes runs the AoC 2020 day 17 rules for six cycles from the example's starting slice.
+func conwayCubes[V aocshared.GridVector[V]](at func(x, y int) V) int {
+	grid := aocshared.SparseGridNCreate[V](false)
+	for y, row := range []string{".#.", "..#", "###"} {
+		for x, c := range row {
+			if c == '#' {
+				grid.Set(at(x, y), true)
+			}
+		}
+	}
+
+	active := func(cell bool) bool { return cell }
+	for range 6 {
+		next := aocshared.SparseGridNCreate[V](false)
+		for v := range grid.Frontier(aocshared.ConnAll) {
+			count := grid.CountNeighbors(v, aocshared.ConnAll, active)
+			if count == 3 || (grid.At(v) && count == 2) {
+				next.Set(v, true)
+			}
+		}
+		grid = next
+	}
+	return grid.Len()
+}
+
+func testVectors(t *testing.T) {
+	// Test 1: Two-dimensional arithmetic and distances
+	t.Run("vec2", func(t *testing.T) {
+		a, b := aocshared.Vec2[int]{X: 1, Y: -2}, aocshared.Vec2[int]{X: 4, Y: 2}
+		assertEqual(t, a.Add(b), aocshared.Vec2[int]{X: 5, Y: 0}, "add")
+		assertEqual(t, a.Sub(b), aocshared.Vec2[int]{X: -3, Y: -4}, "sub")
+		assertEqual(t, a.Scale(3), aocshared.Vec2[int]{X: 3, Y: -6}, "scale")
+		assertEqual(t, a.Dot(b), 0, "perpendicular dot")
+		assertEqual(t, a.Cross(b), 10, "cross")
+		assertEqual(t, a.Manhattan(b), 7, "manhattan")
+		assertEqual(t, a.Chebyshev(b), 4, "chebyshev")
+		assertEqual(t, a.EuclideanSquared(b), 25, "squared euclidean")
+		assertEqualFloat(t, a.Euclidean(b), 5, 1e-9, "euclidean")
+		assertEqual(t, a.Min(b), aocshared.Vec2[int]{X: 1, Y: -2}, "min")
+		assertEqual(t, a.Max(b), aocshared.Vec2[int]{X: 4, Y: 2}, "max")
+
+		f := aocshared.Vec2[float64]{X: 0.5, Y: 1.5}
+		assertEqualFloat(t, f.Scale(2).Manhattan(aocshared.Vec2[float64]{}), 4, 1e-9, "float vectors")
+	})
+
+	// Test 2: Three-dimensional arithmetic and distances
+	t.Run("vec3", func(t *testing.T) {
+		x, y := aocshared.Vec3[int]{X: 1}, aocshared.Vec3[int]{Y: 1}
+		assertEqual(t, x.Cross(y), aocshared.Vec3[int]{Z: 1}, "x cross y is z")
+		assertEqual(t, y.Cross(x), aocshared.Vec3[int]{Z: -1}, "cross product anticommutes")
+
+		a, b := aocshared.Vec3[int]{X: 162, Y: 817, Z: 812}, aocshared.Vec3[int]{X: 425, Y: 690, Z: 689}
+		assertEqual(t, a.Manhattan(b), 263+127+123, "manhattan")
+		assertEqual(t, a.Chebyshev(b), 263, "chebyshev")
+		assertEqual(t, a.EuclideanSquared(b), 263*263+127*127+123*123, "squared euclidean")
+		assertEqualFloat(t, a.Euclidean(b), math.Sqrt(263*263+127*127+123*123), 1e-9, "euclidean")
+		assertEqual(t, a.Min(b), aocshared.Vec3[int]{X: 162, Y: 690, Z: 689}, "min")
+		assertEqual(t, len(a.Offsets(aocshared.ConnOrthogonal)), 6, "orthogonal neighbourhood")
+		assertEqual(t, len(a.Offsets(aocshared.ConnAll)), 26, "full neighbourhood")
+	})
+
+	// Test 3: Vectors with a run-time number of components
+	t.Run("vecn", func(t *testing.T) {
+		a, b := aocshared.VecNOf(1, 2, 3, 4), aocshared.VecNOf(4, 0, 3, -1)
+		assertEqual(t, a.Dims(), 4, "dimensions")
+		assertEqual(t, a.Add(b), aocshared.VecNOf(5, 2, 6, 3), "add")
+		assertEqual(t, a.Sub(b).String(), "[-3 2 0 5]", "sub")
+		assertEqual(t, a.Dot(b), 4+0+9-4, "dot")
+		assertEqual(t, a.Manhattan(b), 10, "manhattan")
+		assertEqual(t, a.Chebyshev(b), 5, "chebyshev")
+		assertEqualFloat(t, a.Euclidean(b), math.Sqrt(38), 1e-9, "euclidean")
+		assertEqual(t, a.Max(b).At(3), 4, "max")
+		assertEqual(t, aocshared.VecNZero[int](4).Add(a), a, "zero is the identity")
+		assertEqual(t, len(a.Offsets(aocshared.ConnOrthogonal)), 8, "orthogonal neighbourhood in 4D")
+		assertEqual(t, len(a.Offsets(aocshared.ConnAll)), 80, "full neighbourhood in 4D")
+		assertTrue(t, aocshared.VecNOf(1, 2) != aocshared.VecNOf(1, 2, 0), "vectors of different sizes should differ", "sizes compared")
+	})
+
+	// Test 4: Sparse grids keyed by vectors
+	t.Run("sparse_grid_n", func(t *testing.T) {
+		grid := aocshared.SparseGridNCreate[aocshared.Vec3[int]](0)
+		grid.Set(aocshared.Vec3[int]{X: 1, Y: -2, Z: 3}, 5)
+		grid.Set(aocshared.Vec3[int]{X: -1, Y: 4, Z: 0}, 7)
+		assertEqual(t, grid.At(aocshared.Vec3[int]{}), 0, "unset cells read as fill")
+
+		minVec, maxVec, ok := grid.Bounds()
+		assertTrue(t, ok, "bounds should exist", "bounds found")
+		assertEqual(t, fmt.Sprint(minVec, maxVec), "{-1 -2 0} {1 4 3}", "bounding box")
+
+		clone := aocshared.SparseGridNClone(grid)
+		clone.Delete(aocshared.Vec3[int]{X: 1, Y: -2, Z: 3})
+		assertEqual(t, grid.Len(), 2, "clone is independent")
+
+		assertEqual(t, grid.CountNeighbors(aocshared.Vec3[int]{X: 1, Y: -2, Z: 2}, aocshared.ConnOrthogonal, func(cell int) bool { return cell > 0 }), 1, "face neighbour counted")
+		// Vectors of different sizes in one grid each get their own neighbourhood.
+		mixed := aocshared.SparseGridNCreate[aocshared.VecN[int]](0)
+		count := func(v aocshared.VecN[int]) int {
+			n := 0
+			for range mixed.Neighbors(v, aocshared.ConnAll) {
+				n++
+			}
+			return n
+		}
+		assertEqual(t, count(aocshared.VecNZero[int](2)), 8, "2D neighbourhood")
+		assertEqual(t, count(aocshared.VecNZero[int](3)), 26, "3D neighbourhood after a 2D one")
+	})
+
+	// Test 5: Conway cubes in three and four dimensions
+	t.Run("conway_cubes", func(t *testing.T) {
+		assertEqual(t, conwayCubes(func(x, y int) aocshared.Vec3[int] { return aocshared.Vec3[int]{X: x, Y: y} }), 112, "3D example")
+		assertEqual(t, conwayCubes(func(x, y int) aocshared.VecN[int] { return aocshared.VecNOf(x, y, 0, 0) }), 848, "4D example")
+	})
+}
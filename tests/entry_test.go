@@ -22,6 +22,63 @@ func TestEntryPoint(t *testing.T) {
 
 	fmt.Println("Testing matrix: ")
 	testMatrix(t)
+
+	fmt.Println("Testing input resolution: ")
+	testInputResolution(t)
+
+	fmt.Println("Testing input encryption: ")
+	testInputCrypto(t)
+
+	fmt.Println("Testing input parsing: ")
+	testInputParsing(t)
+
+	fmt.Println("Testing line decoding: ")
+	testLineDecoding(t)
+
+	fmt.Println("Testing grid parsing: ")
+	testGridParsing(t)
+
+	fmt.Println("Testing input streaming: ")
+	testInputStreaming(t)
+
+	fmt.Println("Testing text blocks: ")
+	testTextBlock(t)
+
+	fmt.Println("Testing grid methods: ")
+	testGridMethods(t)
+
+	fmt.Println("Testing sparse grid: ")
+	testSparseGrid(t)
+
+	fmt.Println("Testing pathfinding: ")
+	testPathfinding(t)
+
+	fmt.Println("Testing regions: ")
+	testRegions(t)
+
+	fmt.Println("Testing grid images: ")
+	testGridImages(t)
+
+	fmt.Println("Testing grid rendering: ")
+	testGridRendering(t)
+
+	fmt.Println("Testing grid transforms: ")
+	testGridTransforms(t)
+
+	fmt.Println("Testing automaton: ")
+	testAutomaton(t)
+
+	fmt.Println("Testing cycle detection: ")
+	testCycleDetection(t)
+
+	fmt.Println("Testing bit grid: ")
+	testBitGrid(t)
+
+	fmt.Println("Testing hex grid: ")
+	testHexGrid(t)
+
+	fmt.Println("Testing vectors: ")
+	testVectors(t)
 }
 
 func testMatrix(t *testing.T) {
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const defaultBaseURL = "https://adventofcode.com"
+
+// siteClient talks to Advent of Code, or to any server mimicking it such as mockaoc.
+type siteClient struct {
+	baseURL   string
+	sessionID string
+	http      *http.Client
+}
+
+func newSiteClient(baseURL, sessionID string) *siteClient {
+	return &siteClient{
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		sessionID: sessionID,
+		http: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// addBaseURLFlag registers -base-url on a command's flag set.
+func addBaseURLFlag(flags *flag.FlagSet) *string {
+	return flags.String("base-url", "", "site to talk to (defaults to base_url in config.toml, then "+defaultBaseURL+")")
+}
+
+// clientFromSettings picks the base URL from the flag, then the config, then the real site.
+func clientFromSettings(settings Settings, baseURLFlag string) *siteClient {
+	baseURL := defaultBaseURL
+	if settings.BaseURL != "" {
+		baseURL = settings.BaseURL
+	}
+	if baseURLFlag != "" {
+		baseURL = baseURLFlag
+	}
+
+	return newSiteClient(baseURL, settings.Key)
+}
+
+func inputPath(year, day int) string {
+	return fmt.Sprintf("/%d/day/%d/input", year, day)
+}
+
+func puzzlePath(year, day int) string {
+	return fmt.Sprintf("/%d/day/%d", year, day)
+}
+
+func answerPath(year, day int) string {
+	return fmt.Sprintf("/%d/day/%d/answer", year, day)
+}
+
+func leaderboardPath(year int, id string) string {
+	return fmt.Sprintf("/%d/leaderboard/private/view/%s.json", year, id)
+}
+
+// get handles authenticated HTTP transport. The body is returned untouched.
+func (c *siteClient) get(path string) ([]byte, error) {
+	req, err := http.NewRequest("GET", c.baseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	return c.do(req)
+}
+
+// postForm submits form values with the session cookie attached.
+func (c *siteClient) postForm(path string, form url.Values) ([]byte, error) {
+	req, err := http.NewRequest("POST", c.baseURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return c.do(req)
+}
+
+func (c *siteClient) do(req *http.Request) ([]byte, error) {
+	req.AddCookie(&http.Cookie{
+		Name:  "session",
+		Value: c.sessionID,
+	})
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &statusError{StatusCode: resp.StatusCode}
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+
+	return data, nil
+}
+
+// statusError reports a non-200 answer, so callers can react to specific codes.
+type statusError struct {
+	StatusCode int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("server returned error status: %d", e.StatusCode)
+}
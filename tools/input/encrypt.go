@@ -0,0 +1,94 @@
+package main
+
+import (
+	aocshared "aoc_shared"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// runEncryptInputs encrypts every plaintext src/<year>/day<N>/input.txt in place.
+// A key is generated on first use. Checksums keep describing the plaintext.
+func runEncryptInputs() {
+	key, err := aocshared.InputKeyLoad()
+	if errors.Is(err, os.ErrNotExist) {
+		path, genErr := aocshared.InputKeyGenerate()
+		if genErr != nil {
+			log.Fatalf("Failed to generate input key: %v", genErr)
+		}
+
+		fmt.Printf("Generated a new input key at: %s (back it up, inputs cannot be read without it)\n", path)
+		key, err = aocshared.InputKeyLoad()
+	}
+	if err != nil {
+		log.Fatalf("Failed to load input key: %v", err)
+	}
+
+	paths, err := filepath.Glob(filepath.Join("src", "*", "day*", "input.txt"))
+	if err != nil {
+		log.Fatalf("Failed to list inputs: %v", err)
+	}
+
+	manifest, err := aocshared.InputManifestLoad(filepath.Join("src", aocshared.InputManifestFileName))
+	if err != nil {
+		log.Fatalf("Failed to load manifest: %v", err)
+	}
+
+	encrypted := 0
+	for _, path := range paths {
+		changed, err := encryptInputFile(key, manifest, path)
+		if err != nil {
+			log.Fatalf("Failed to encrypt %s: %v", path, err)
+		}
+
+		if changed {
+			fmt.Printf("Encrypted: %s\n", path)
+			encrypted++
+		}
+	}
+
+	if err := aocshared.InputManifestSave(manifest); err != nil {
+		log.Fatalf("Failed to save manifest: %v", err)
+	}
+
+	fmt.Printf("Encrypted %d of %d inputs.\n", encrypted, len(paths))
+}
+
+// encryptInputFile replaces a plaintext input with its encrypted form via a temporary file,
+// so an interrupted run never leaves a half written input behind.
+func encryptInputFile(key []byte, manifest *aocshared.InputManifest, path string) (bool, error) {
+	plaintext, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	if aocshared.InputIsEncrypted(plaintext) {
+		return false, nil
+	}
+
+	rel, err := filepath.Rel("src", path)
+	if err != nil {
+		return false, err
+	}
+
+	entry := filepath.ToSlash(rel)
+	if err := aocshared.InputManifestVerify(manifest, entry, plaintext); errors.Is(err, aocshared.ErrInputUnrecorded) {
+		aocshared.InputManifestRecord(manifest, entry, plaintext)
+	} else if err != nil {
+		fmt.Printf("WARNING: %v (encrypting the current contents anyway)\n", err)
+	}
+
+	sealed, err := aocshared.InputEncrypt(key, plaintext)
+	if err != nil {
+		return false, err
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, sealed, 0644); err != nil {
+		return false, err
+	}
+
+	return true, os.Rename(tmpPath, path)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	aocshared "aoc_shared"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEncryptInputFile(t *testing.T) {
+	key := bytes.Repeat([]byte{0x42}, 32)
+	plaintext := []byte("L68\nR48\n")
+
+	t.Chdir(t.TempDir())
+	path := filepath.Join("src", "2025", "day1", "input.txt")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, plaintext, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest, err := aocshared.InputManifestLoad(filepath.Join("src", aocshared.InputManifestFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changed, err := encryptInputFile(key, manifest, path)
+	if err != nil || !changed {
+		t.Fatalf("first run: expected the input to be encrypted, got changed=%v err=%v", changed, err)
+	}
+
+	sealed, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("replaced_in_place", func(t *testing.T) {
+		if !aocshared.InputIsEncrypted(sealed) {
+			t.Fatalf("input was not encrypted: %q", sealed)
+		}
+		opened, err := aocshared.InputDecrypt(key, sealed)
+		if err != nil || !bytes.Equal(opened, plaintext) {
+			t.Errorf("expected %q back, got %q (err %v)", plaintext, opened, err)
+		}
+		if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+			t.Errorf("temporary file left behind: %v", err)
+		}
+	})
+
+	t.Run("checksum_of_plaintext", func(t *testing.T) {
+		if err := aocshared.InputManifestVerify(manifest, aocshared.InputManifestKey(2025, 1), plaintext); err != nil {
+			t.Errorf("plaintext checksum not recorded: %v", err)
+		}
+	})
+
+	t.Run("second_run_is_a_no_op", func(t *testing.T) {
+		changed, err := encryptInputFile(key, manifest, path)
+		if err != nil || changed {
+			t.Fatalf("second run: expected nothing to do, got changed=%v err=%v", changed, err)
+		}
+
+		again, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(again, sealed) {
+			t.Error("already encrypted input was rewritten")
+		}
+	})
+}
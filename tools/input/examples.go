@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+var exampleBlockRegex = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+var htmlTagRegex = regexp.MustCompile(`<[^>]+>`)
+
+// extractExamples returns the text of every <pre><code> block on a puzzle page, in page order.
+func extractExamples(page []byte) []string {
+	matches := exampleBlockRegex.FindAllSubmatch(page, -1)
+	examples := make([]string, 0, len(matches))
+
+	for _, match := range matches {
+		text := htmlTagRegex.ReplaceAllString(string(match[1]), "")
+		examples = append(examples, html.UnescapeString(text))
+	}
+
+	return examples
+}
+
+// saveExamples writes the blocks as test_input.txt, test_input_2.txt, ... matching aocshared.GetTestInputN.
+// Files that already exist are left alone, as they are often trimmed by hand.
+func saveExamples(year, day int, examples []string) error {
+	dirPath := filepath.Join("src", fmt.Sprintf("%d", year), fmt.Sprintf("day%d", day))
+
+	if err := os.MkdirAll(dirPath, 0755); err != nil {
+		return fmt.Errorf("failed to create directories: %w", err)
+	}
+
+	for i, example := range examples {
+		fileName := "test_input.txt"
+		if i > 0 {
+			fileName = fmt.Sprintf("test_input_%d.txt", i+1)
+		}
+
+		filePath := filepath.Join(dirPath, fileName)
+		if _, err := os.Stat(filePath); err == nil {
+			fmt.Printf("Keeping existing example: %s\n", filePath)
+			continue
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to check example file: %w", err)
+		}
+
+		if err := os.WriteFile(filePath, []byte(example), 0644); err != nil {
+			return fmt.Errorf("failed to write example: %w", err)
+		}
+
+		fmt.Printf("Saved example to: %s\n", filePath)
+	}
+
+	return nil
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode"
+)
+
+// leaderboardCacheTTL follows the site's request to poll private leaderboards at most every 15 minutes.
+const leaderboardCacheTTL = 15 * time.Minute
+
+var leaderboardCacheDir = filepath.Join("build", "cache")
+
+type leaderboard struct {
+	Event   string                       `json:"event"`
+	OwnerID int                          `json:"owner_id"`
+	Members map[string]leaderboardMember `json:"members"`
+}
+
+type leaderboardMember struct {
+	ID                 int                                  `json:"id"`
+	Name               string                               `json:"name"`
+	Stars              int                                  `json:"stars"`
+	LocalScore         int                                  `json:"local_score"`
+	LastStarTS         int64                                `json:"last_star_ts"`
+	CompletionDayLevel map[string]map[string]starCompletion `json:"completion_day_level"`
+}
+
+type starCompletion struct {
+	GetStarTS int64 `json:"get_star_ts"`
+	StarIndex int64 `json:"star_index"`
+}
+
+// runLeaderboard handles `leaderboard [-year N] [-file path] [-base-url URL] <id>`.
+func runLeaderboard(args []string) {
+	flags := flag.NewFlagSet("leaderboard", flag.ExitOnError)
+	year := flags.Int("year", defaultEventYear(time.Now()), "event year")
+	file := flags.String("file", "", "read the leaderboard from a local JSON file instead of the site")
+	baseURL := addBaseURLFlag(flags)
+	if err := flags.Parse(args); err != nil {
+		log.Fatal(err)
+	}
+
+	var data []byte
+	var err error
+
+	if *file != "" {
+		data, err = os.ReadFile(*file)
+	} else {
+		if flags.NArg() != 1 {
+			log.Fatal("Usage: leaderboard [-year N] [-file path] [-base-url URL] <id>")
+		}
+		data, err = loadLeaderboardCached(clientFromSettings(loadConfig(), *baseURL), *year, flags.Arg(0))
+	}
+	if err != nil {
+		log.Fatalf("Failed to load leaderboard: %v", err)
+	}
+
+	board, err := parseLeaderboard(data)
+	if err != nil {
+		log.Fatalf("Failed to parse leaderboard: %v", err)
+	}
+
+	if board.Event != "" {
+		if eventYear, err := strconv.Atoi(board.Event); err == nil {
+			*year = eventYear
+		}
+	}
+
+	renderLeaderboard(os.Stdout, board, *year)
+}
+
+// defaultEventYear picks the running event in December and the previous one otherwise.
+func defaultEventYear(now time.Time) int {
+	if now.Month() == time.December {
+		return now.Year()
+	}
+	return now.Year() - 1
+}
+
+// loadLeaderboardCached serves the leaderboard from disk while the cached copy is fresh.
+// Copies are kept per site, so a mock server never answers for the real one or the other way round.
+func loadLeaderboardCached(client *siteClient, year int, id string) ([]byte, error) {
+	cachePath := filepath.Join(leaderboardCacheDir, fmt.Sprintf("leaderboard_%s_%d_%s.json", cacheSiteName(client.baseURL), year, id))
+
+	if info, err := os.Stat(cachePath); err == nil {
+		if age := time.Since(info.ModTime()); age < leaderboardCacheTTL {
+			fmt.Printf("Using cached leaderboard (refreshes in %s)\n", (leaderboardCacheTTL - age).Round(time.Second))
+			return os.ReadFile(cachePath)
+		}
+	}
+
+	data, err := client.get(leaderboardPath(year, id))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(leaderboardCacheDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	if err := os.WriteFile(cachePath, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write cache: %w", err)
+	}
+
+	return data, nil
+}
+
+// cacheSiteName turns a base URL into a file name safe label such as "adventofcode.com" or "127.0.0.1_8080".
+func cacheSiteName(baseURL string) string {
+	site := baseURL
+	if parsed, err := url.Parse(baseURL); err == nil && parsed.Host != "" {
+		site = parsed.Host + parsed.Path
+	}
+
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, site)
+}
+
+func parseLeaderboard(data []byte) (leaderboard, error) {
+	var board leaderboard
+	if err := json.Unmarshal(data, &board); err != nil {
+		return leaderboard{}, err
+	}
+	return board, nil
+}
+
+// rankMembers orders by local score, then stars, then who got their last star first.
+func rankMembers(board leaderboard) []leaderboardMember {
+	members := make([]leaderboardMember, 0, len(board.Members))
+	for _, member := range board.Members {
+		members = append(members, member)
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		a, b := members[i], members[j]
+		if a.LocalScore != b.LocalScore {
+			return a.LocalScore > b.LocalScore
+		}
+		if a.Stars != b.Stars {
+			return a.Stars > b.Stars
+		}
+		if a.LastStarTS != b.LastStarTS {
+			return a.LastStarTS < b.LastStarTS
+		}
+		return a.ID < b.ID
+	})
+
+	return members
+}
+
+// completedDays lists every day on which at least one member earned a star.
+func completedDays(members []leaderboardMember) []int {
+	seen := make(map[int]bool)
+	for _, member := range members {
+		for dayKey := range member.CompletionDayLevel {
+			if day, err := strconv.Atoi(dayKey); err == nil {
+				seen[day] = true
+			}
+		}
+	}
+
+	days := make([]int, 0, len(seen))
+	for day := range seen {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+
+	return days
+}
+
+func renderLeaderboard(w io.Writer, board leaderboard, year int) {
+	members := rankMembers(board)
+	days := completedDays(members)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	header := []string{"#", "Name", "Score", "Stars"}
+	for _, day := range days {
+		header = append(header, fmt.Sprintf("Day %d", day))
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	for rank, member := range members {
+		row := []string{
+			strconv.Itoa(rank + 1),
+			memberName(member),
+			strconv.Itoa(member.LocalScore),
+			strconv.Itoa(member.Stars),
+		}
+		for _, day := range days {
+			row = append(row, formatDayCell(member, year, day))
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	tw.Flush()
+}
+
+func memberName(member leaderboardMember) string {
+	if member.Name == "" {
+		return fmt.Sprintf("(anonymous user #%d)", member.ID)
+	}
+	return member.Name
+}
+
+// formatDayCell shows the part 1 completion time since unlock and the part 1 to part 2 delta.
+func formatDayCell(member leaderboardMember, year, day int) string {
+	levels, ok := member.CompletionDayLevel[strconv.Itoa(day)]
+	if !ok {
+		return "-"
+	}
+
+	part1, ok := levels["1"]
+	if !ok {
+		return "-"
+	}
+
+	part1Time := time.Unix(part1.GetStarTS, 0)
+	cell := formatSplit(part1Time.Sub(puzzleUnlock(year, day)))
+
+	if part2, ok := levels["2"]; ok {
+		cell += " +" + formatSplit(time.Unix(part2.GetStarTS, 0).Sub(part1Time))
+	}
+
+	return cell
+}
+
+// formatSplit prints durations as hh:mm:ss, switching to days once past 24 hours.
+func formatSplit(duration time.Duration) string {
+	if duration < 0 {
+		duration = 0
+	}
+
+	duration = duration.Round(time.Second)
+	if duration >= 24*time.Hour {
+		days := int(duration / (24 * time.Hour))
+		hours := int((duration % (24 * time.Hour)) / time.Hour)
+		return fmt.Sprintf("%dd%02dh", days, hours)
+	}
+
+	hours := int(duration / time.Hour)
+	minutes := int((duration % time.Hour) / time.Minute)
+	seconds := int((duration % time.Minute) / time.Second)
+
+	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+}
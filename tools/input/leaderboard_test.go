@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLeaderboardFixture(t *testing.T) {
+	data, err := os.ReadFile("testdata/mock/2025/leaderboard/12345.json")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	board, err := parseLeaderboard(data)
+	if err != nil {
+		t.Fatalf("failed to parse fixture: %v", err)
+	}
+
+	t.Run("ranking", func(t *testing.T) {
+		members := rankMembers(board)
+		names := []string{memberName(members[0]), memberName(members[1]), memberName(members[2])}
+		expected := []string{"Alice", "Bob", "(anonymous user #3)"}
+
+		for i := range expected {
+			if names[i] != expected[i] {
+				t.Errorf("rank %d: expected %s, got %s", i+1, expected[i], names[i])
+			}
+		}
+	})
+
+	t.Run("day_cells", func(t *testing.T) {
+		alice := board.Members["1"]
+
+		if cell := formatDayCell(alice, 2025, 1); cell != "00:10:00 +00:05:30" {
+			t.Errorf("unexpected day 1 cell: %q", cell)
+		}
+		if cell := formatDayCell(alice, 2025, 2); cell != "01:00:00" {
+			t.Errorf("unexpected day 2 cell: %q", cell)
+		}
+		if cell := formatDayCell(alice, 2025, 3); cell != "-" {
+			t.Errorf("unexpected day 3 cell: %q", cell)
+		}
+	})
+
+	t.Run("render", func(t *testing.T) {
+		var out bytes.Buffer
+		renderLeaderboard(&out, board, 2025)
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		if len(lines) != 4 {
+			t.Fatalf("expected header and 3 rows, got %d lines:\n%s", len(lines), out.String())
+		}
+		if !strings.Contains(lines[0], "Day 1") || !strings.Contains(lines[0], "Day 2") {
+			t.Errorf("header is missing day columns: %q", lines[0])
+		}
+		if !strings.HasPrefix(lines[1], "1") || !strings.Contains(lines[1], "Alice") {
+			t.Errorf("expected Alice first, got %q", lines[1])
+		}
+	})
+}
+
+func TestFormatSplit(t *testing.T) {
+	cases := map[time.Duration]string{
+		0:                                  "00:00:00",
+		90 * time.Second:                   "00:01:30",
+		25*time.Hour + 30*time.Minute:      "1d01h",
+		-5 * time.Second:                   "00:00:00",
+		3*time.Hour + 400*time.Millisecond: "03:00:00",
+	}
+
+	for duration, expected := range cases {
+		if got := formatSplit(duration); got != expected {
+			t.Errorf("formatSplit(%s): expected %s, got %s", duration, expected, got)
+		}
+	}
+}
@@ -1,11 +1,11 @@
 package main
 
 import (
-	"bytes"
+	aocshared "aoc_shared"
+	"flag"
 	"fmt"
 	"io"
 	"log"
-	"net/http"
 	"os"
 	"path/filepath"
 	"time"
@@ -16,26 +16,104 @@ import (
 const configPath = "config.toml"
 
 type Settings struct {
-	Key string `toml:"key"`
+	Key           string `toml:"key"`
+	EncryptInputs bool   `toml:"encrypt_inputs"`
+	BaseURL       string `toml:"base_url"`
 }
 
 func main() {
+	if len(os.Args) < 2 {
+		runFetch(nil)
+		return
+	}
+
+	switch os.Args[1] {
+	case "fetch":
+		runFetch(os.Args[2:])
+	case "leaderboard":
+		runLeaderboard(os.Args[2:])
+	case "submit":
+		runSubmit(os.Args[2:])
+	case "encrypt-inputs":
+		runEncryptInputs()
+	case "mock-server":
+		runMockServer(os.Args[2:])
+	default:
+		log.Fatalf("Unknown command %q (expected fetch, leaderboard, submit, encrypt-inputs or mock-server)", os.Args[1])
+	}
+}
+
+// runFetch handles `fetch [-wait] [-year N -day N] [-base-url URL]`. Without a puzzle or -wait it asks interactively.
+func runFetch(args []string) {
+	flags := flag.NewFlagSet("fetch", flag.ExitOnError)
+	wait := flags.Bool("wait", false, "wait for the puzzle to unlock, then fetch its input and examples")
+	year := flags.Int("year", 0, "puzzle year")
+	day := flags.Int("day", 0, "puzzle day")
+	baseURL := addBaseURLFlag(flags)
+	if err := flags.Parse(args); err != nil {
+		log.Fatal(err)
+	}
+
 	settings := loadConfig()
-	year, day := getUserInput()
+	client := clientFromSettings(settings, *baseURL)
+	clk := systemClock{}
+
+	if *year == 0 || *day == 0 {
+		if *wait {
+			*year, *day = nextUnlock(clk.Now())
+		} else {
+			*year, *day = getUserInput()
+		}
+	}
 
-	url := fmt.Sprintf("https://adventofcode.com/%d/day/%d/input", year, day)
+	if err := downloadPuzzle(client, clk, os.Stdout, *year, *day, *wait, settings.EncryptInputs); err != nil {
+		log.Fatal(err)
+	}
 
-	fmt.Printf("Fetching input for Year: %d, Day: %d...\n", year, day)
-	data, err := fetchInput(url, settings.Key)
+	fmt.Println("Done.")
+}
+
+// downloadPuzzle saves the input of a puzzle. With wait set it first waits for the unlock,
+// retries early 404s and also saves the examples from the puzzle page.
+func downloadPuzzle(client *siteClient, clk clock, out io.Writer, year, day int, wait, encrypt bool) error {
+	fetch := client.get
+
+	if wait {
+		unlock := puzzleUnlock(year, day)
+		fmt.Fprintf(out, "Year %d, Day %d unlocks at %s\n", year, day, unlock.Local().Format(time.DateTime))
+		waitForUnlock(clk, out, unlock)
+
+		fetch = func(path string) ([]byte, error) {
+			return fetchWithRetry(clk, out, func() ([]byte, error) {
+				return client.get(path)
+			})
+		}
+	}
+
+	fmt.Fprintf(out, "Fetching input for Year: %d, Day: %d...\n", year, day)
+	data, err := fetch(inputPath(year, day))
 	if err != nil {
-		log.Fatalf("Failed to fetch input: %v", err)
+		return fmt.Errorf("failed to fetch input: %w", err)
 	}
 
-	if err := saveInput(year, day, data); err != nil {
-		log.Fatalf("Failed to save file: %v", err)
+	if err := saveInput(year, day, data, encrypt); err != nil {
+		return fmt.Errorf("failed to save file: %w", err)
 	}
 
-	fmt.Println("Done.")
+	if !wait {
+		return nil
+	}
+
+	page, err := fetch(puzzlePath(year, day))
+	if err != nil {
+		return fmt.Errorf("failed to fetch puzzle page: %w", err)
+	}
+
+	if err := saveExamples(year, day, extractExamples(page)); err != nil {
+		return fmt.Errorf("failed to save examples: %w", err)
+	}
+
+	return nil
 }
 
 // loadConfig isolates the configuration logic.
@@ -67,53 +145,49 @@ func getUserInput() (int, int) {
 	return year, day
 }
 
-// saveInput handles directory creation and file writing.
-func saveInput(year, day int, data []byte) error {
+// saveInput handles directory creation, file writing and checksum recording.
+// The data is written byte for byte; normalisation happens at parse time in aoc_shared.
+// With encrypt set the file is sealed with the key from aocshared.InputKeyPath.
+func saveInput(year, day int, data []byte, encrypt bool) error {
 	dirPath := filepath.Join("src", fmt.Sprintf("%d", year), fmt.Sprintf("day%d", day))
 
 	if err := os.MkdirAll(dirPath, 0755); err != nil {
 		return fmt.Errorf("failed to create directories: %w", err)
 	}
 
+	stored := data
+	if encrypt {
+		key, err := aocshared.InputKeyLoad()
+		if err != nil {
+			return fmt.Errorf("failed to load input key (run encrypt-inputs once to create it): %w", err)
+		}
+
+		if stored, err = aocshared.InputEncrypt(key, data); err != nil {
+			return fmt.Errorf("failed to encrypt input: %w", err)
+		}
+	}
+
 	filePath := filepath.Join(dirPath, "input.txt")
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
+	if err := os.WriteFile(filePath, stored, 0644); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
 	fmt.Printf("Saved input to: %s\n", filePath)
-	return nil
-}
 
-// fetchInput handles HTTP transport and data normalization.
-func fetchInput(url, sessionID string) ([]byte, error) {
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	if err := recordChecksum(year, day, data); err != nil {
+		return fmt.Errorf("failed to record checksum: %w", err)
 	}
 
-	req.AddCookie(&http.Cookie{
-		Name:  "session",
-		Value: sessionID,
-	})
-
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("server returned error status: %d", resp.StatusCode)
-	}
+	return nil
+}
 
-	data, err := io.ReadAll(resp.Body)
+// recordChecksum stores the SHA-256 of the input in the manifest the runner verifies against.
+func recordChecksum(year, day int, data []byte) error {
+	manifest, err := aocshared.InputManifestLoad(filepath.Join("src", aocshared.InputManifestFileName))
 	if err != nil {
-		return nil, fmt.Errorf("failed to read body: %w", err)
+		return err
 	}
 
-	return bytes.TrimSpace(data), nil
+	aocshared.InputManifestRecord(manifest, aocshared.InputManifestKey(year, day), data)
+	return aocshared.InputManifestSave(manifest)
 }
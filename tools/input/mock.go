@@ -0,0 +1,24 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"input/mockaoc"
+	"log"
+	"net/http"
+)
+
+// runMockServer handles `mock-server [-addr host:port] [-fixtures dir] [-session id]`.
+// Point other commands at it with -base-url to work offline.
+func runMockServer(args []string) {
+	flags := flag.NewFlagSet("mock-server", flag.ExitOnError)
+	addr := flags.String("addr", "127.0.0.1:8080", "listen address")
+	fixtures := flags.String("fixtures", "tools/input/testdata/mock", "fixtures directory")
+	session := flags.String("session", "mock-session", "session cookie value the server accepts")
+	if err := flags.Parse(args); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Serving %s on http://%s (session %q)\n", *fixtures, *addr, *session)
+	log.Fatal(http.ListenAndServe(*addr, mockaoc.New(*fixtures, *session)))
+}
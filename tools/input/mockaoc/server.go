@@ -0,0 +1,185 @@
+// Package mockaoc is a stand-in for the Advent of Code site, serving canned responses from a fixtures directory.
+//
+// Fixture layout:
+//
+//	<year>/day<N>/input.txt         puzzle input, served byte for byte
+//	<year>/day<N>/puzzle.html       puzzle page
+//	<year>/day<N>/answers.json      {"1": "...", "2": "..."} accepted answers per level
+//	<year>/leaderboard/<id>.json    private leaderboard
+package mockaoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCooldown mirrors the site's one minute wait after a wrong answer.
+const DefaultCooldown = time.Minute
+
+type Server struct {
+	FixturesDir string
+	SessionID   string
+	Cooldown    time.Duration
+	Now         func() time.Time
+
+	mux *http.ServeMux
+
+	mu          sync.Mutex
+	lockedUntil time.Time
+}
+
+func New(fixturesDir, sessionID string) *Server {
+	server := &Server{
+		FixturesDir: fixturesDir,
+		SessionID:   sessionID,
+		Cooldown:    DefaultCooldown,
+		Now:         time.Now,
+		mux:         http.NewServeMux(),
+	}
+
+	server.mux.HandleFunc("GET /{year}/day/{day}/input", server.handleInput)
+	server.mux.HandleFunc("GET /{year}/day/{day}", server.handlePuzzle)
+	server.mux.HandleFunc("POST /{year}/day/{day}/answer", server.handleAnswer)
+	server.mux.HandleFunc("GET /{year}/leaderboard/private/view/{file}", server.handleLeaderboard)
+
+	return server
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleInput(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(w, r) {
+		return
+	}
+
+	dayDir, ok := s.dayDir(w, r)
+	if !ok {
+		return
+	}
+
+	s.serveFixture(w, filepath.Join(dayDir, "input.txt"), "text/plain")
+}
+
+func (s *Server) handlePuzzle(w http.ResponseWriter, r *http.Request) {
+	dayDir, ok := s.dayDir(w, r)
+	if !ok {
+		return
+	}
+
+	s.serveFixture(w, filepath.Join(dayDir, "puzzle.html"), "text/html")
+}
+
+func (s *Server) handleAnswer(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(w, r) {
+		return
+	}
+
+	dayDir, ok := s.dayDir(w, r)
+	if !ok {
+		return
+	}
+
+	content, err := os.ReadFile(filepath.Join(dayDir, "answers.json"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var answers map[string]string
+	if err := json.Unmarshal(content, &answers); err != nil {
+		http.Error(w, fmt.Sprintf("bad answers fixture: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.Now()
+	if now.Before(s.lockedUntil) {
+		left := s.lockedUntil.Sub(now).Round(time.Second)
+		writeArticle(w, fmt.Sprintf("You gave an answer too recently; you have to wait after submitting an answer before trying again. You have %s left to wait.", left))
+		return
+	}
+
+	expected, ok := answers[r.FormValue("level")]
+	if !ok {
+		writeArticle(w, "You don't seem to be solving the right level. Did you already complete it?")
+		return
+	}
+
+	if strings.TrimSpace(r.FormValue("answer")) != expected {
+		s.lockedUntil = now.Add(s.Cooldown)
+		writeArticle(w, "That's not the right answer. Please wait one minute before trying again.")
+		return
+	}
+
+	writeArticle(w, "That's the right answer! You are one gold star closer to finishing.")
+}
+
+func (s *Server) handleLeaderboard(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(w, r) {
+		return
+	}
+
+	year, err := strconv.Atoi(r.PathValue("year"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	id, found := strings.CutSuffix(r.PathValue("file"), ".json")
+	if _, err := strconv.Atoi(id); !found || err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.serveFixture(w, filepath.Join(s.FixturesDir, strconv.Itoa(year), "leaderboard", id+".json"), "application/json")
+}
+
+// authorized checks the session cookie the same way the site gates per-user content.
+func (s *Server) authorized(w http.ResponseWriter, r *http.Request) bool {
+	cookie, err := r.Cookie("session")
+	if err != nil || cookie.Value != s.SessionID {
+		http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// dayDir resolves the fixture folder of a puzzle. Numbers are re-formatted so paths cannot escape the fixtures.
+func (s *Server) dayDir(w http.ResponseWriter, r *http.Request) (string, bool) {
+	year, errYear := strconv.Atoi(r.PathValue("year"))
+	day, errDay := strconv.Atoi(r.PathValue("day"))
+	if errYear != nil || errDay != nil {
+		http.NotFound(w, r)
+		return "", false
+	}
+
+	return filepath.Join(s.FixturesDir, strconv.Itoa(year), fmt.Sprintf("day%d", day)), true
+}
+
+// serveFixture answers 404 for missing files, which is also what the site does before a day unlocks.
+func (s *Server) serveFixture(w http.ResponseWriter, path, contentType string) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		http.Error(w, "404 Not Found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Write(content)
+}
+
+func writeArticle(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "text/html")
+	fmt.Fprintf(w, "<html><body><main><article><p>%s</p></article></main></body></html>", message)
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	aocshared "aoc_shared"
+	"errors"
+	"input/mockaoc"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const mockSession = "test-session"
+
+// startMockSite serves testdata/mock and returns a client pointed at it.
+func startMockSite(t *testing.T) (*mockaoc.Server, *siteClient) {
+	t.Helper()
+
+	fixtures, err := filepath.Abs(filepath.Join("testdata", "mock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock := mockaoc.New(fixtures, mockSession)
+	server := httptest.NewServer(mock)
+	t.Cleanup(server.Close)
+
+	return mock, newSiteClient(server.URL, mockSession)
+}
+
+func TestDownloadPuzzle(t *testing.T) {
+	expected, err := os.ReadFile(filepath.Join("testdata", "mock", "2025", "day1", "input.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, client := startMockSite(t)
+	t.Chdir(t.TempDir())
+
+	clk := &fakeClock{now: puzzleUnlock(2025, 1).Add(time.Hour)}
+	if err := downloadPuzzle(client, clk, io.Discard, 2025, 1, true, false); err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+
+	t.Run("raw_input", func(t *testing.T) {
+		saved, err := os.ReadFile(filepath.Join("src", "2025", "day1", "input.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(saved) != string(expected) {
+			t.Errorf("input was altered: expected %q, got %q", expected, saved)
+		}
+	})
+
+	t.Run("checksum", func(t *testing.T) {
+		manifest, err := aocshared.InputManifestLoad(filepath.Join("src", aocshared.InputManifestFileName))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := aocshared.InputManifestVerify(manifest, aocshared.InputManifestKey(2025, 1), expected); err != nil {
+			t.Errorf("checksum not recorded: %v", err)
+		}
+	})
+
+	t.Run("examples", func(t *testing.T) {
+		examples := map[string]string{
+			"test_input.txt":   "L68\nR48\n",
+			"test_input_2.txt": "1 < 2\n",
+		}
+		for name, content := range examples {
+			saved, err := os.ReadFile(filepath.Join("src", "2025", "day1", name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(saved) != content {
+				t.Errorf("%s: expected %q, got %q", name, content, saved)
+			}
+		}
+	})
+}
+
+func TestSessionIsRequired(t *testing.T) {
+	_, client := startMockSite(t)
+	client.sessionID = "someone-else"
+
+	_, err := client.get(inputPath(2025, 1))
+
+	var statusErr *statusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected a 400, got %v", err)
+	}
+}
+
+func TestLockedDayRetriesThenGivesUp(t *testing.T) {
+	_, client := startMockSite(t)
+	clk := &fakeClock{now: time.Now()}
+
+	_, err := fetchWithRetry(clk, io.Discard, func() ([]byte, error) {
+		return client.get(inputPath(2025, 2))
+	})
+
+	if err == nil {
+		t.Fatal("expected an error for a day without fixtures")
+	}
+	if clk.slept != (unlockRetryAttempts-1)*unlockRetryDelay {
+		t.Errorf("expected %d waits between attempts, slept %s", unlockRetryAttempts-1, clk.slept)
+	}
+}
+
+func TestSubmitAnswer(t *testing.T) {
+	mock, client := startMockSite(t)
+	now := time.Date(2025, time.December, 1, 6, 0, 0, 0, time.UTC)
+	mock.Now = func() time.Time { return now }
+
+	steps := []struct {
+		name    string
+		advance time.Duration
+		level   int
+		answer  string
+		outcome submitOutcome
+	}{
+		{"wrong", 0, 1, "4", submitWrong},
+		{"too_soon", 10 * time.Second, 1, "3", submitTooSoon},
+		{"right_after_cooldown", mockaoc.DefaultCooldown, 1, "3", submitCorrect},
+		{"unknown_level", 0, 3, "1", submitAlreadyDone},
+	}
+
+	for _, step := range steps {
+		now = now.Add(step.advance)
+
+		outcome, message, err := submitAnswer(client, 2025, 1, step.level, step.answer)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if outcome != step.outcome {
+			t.Errorf("%s: expected %s, got %s (%q)", step.name, step.outcome, outcome, message)
+		}
+	}
+}
+
+func TestLeaderboardIsCached(t *testing.T) {
+	_, client := startMockSite(t)
+
+	previousCacheDir := leaderboardCacheDir
+	leaderboardCacheDir = t.TempDir()
+	t.Cleanup(func() { leaderboardCacheDir = previousCacheDir })
+
+	first, err := loadLeaderboardCached(client, 2025, "12345")
+	if err != nil {
+		t.Fatalf("fetch failed: %v", err)
+	}
+
+	if _, err := parseLeaderboard(first); err != nil {
+		t.Fatalf("fetched leaderboard does not parse: %v", err)
+	}
+
+	// The mock rejects a wrong session, so a successful second read never left the cache.
+	unauthorized := newSiteClient(client.baseURL, "wrong-session")
+	second, err := loadLeaderboardCached(unauthorized, 2025, "12345")
+	if err != nil || string(second) != string(first) {
+		t.Errorf("expected the cached copy, got error %v", err)
+	}
+
+	// Another site has its own cache entry, so a dead address has nothing to fall back on.
+	other := newSiteClient("http://127.0.0.1:1", mockSession)
+	if _, err := loadLeaderboardCached(other, 2025, "12345"); err == nil {
+		t.Error("expected another site to miss the cache")
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"html"
+	"log"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type submitOutcome int
+
+const (
+	submitUnknown submitOutcome = iota
+	submitCorrect
+	submitWrong
+	submitTooSoon
+	submitAlreadyDone
+)
+
+func (o submitOutcome) String() string {
+	switch o {
+	case submitCorrect:
+		return "correct"
+	case submitWrong:
+		return "wrong"
+	case submitTooSoon:
+		return "too soon"
+	case submitAlreadyDone:
+		return "already done"
+	default:
+		return "unknown"
+	}
+}
+
+var articleRegex = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+
+// runSubmit handles `submit -year N -day N -level 1|2 [-base-url URL] <answer>`.
+func runSubmit(args []string) {
+	flags := flag.NewFlagSet("submit", flag.ExitOnError)
+	year := flags.Int("year", 0, "puzzle year")
+	day := flags.Int("day", 0, "puzzle day")
+	level := flags.Int("level", 1, "puzzle part (1 or 2)")
+	baseURL := addBaseURLFlag(flags)
+	if err := flags.Parse(args); err != nil {
+		log.Fatal(err)
+	}
+
+	if *year == 0 || *day == 0 || flags.NArg() != 1 {
+		log.Fatal("Usage: submit -year N -day N -level 1|2 [-base-url URL] <answer>")
+	}
+
+	client := clientFromSettings(loadConfig(), *baseURL)
+
+	outcome, message, err := submitAnswer(client, *year, *day, *level, flags.Arg(0))
+	if err != nil {
+		log.Fatalf("Failed to submit answer: %v", err)
+	}
+
+	fmt.Printf("[%s] %s\n", outcome, message)
+}
+
+// submitAnswer posts an answer and classifies the site's reply.
+func submitAnswer(client *siteClient, year, day, level int, answer string) (submitOutcome, string, error) {
+	form := url.Values{
+		"level":  {strconv.Itoa(level)},
+		"answer": {answer},
+	}
+
+	page, err := client.postForm(answerPath(year, day), form)
+	if err != nil {
+		return submitUnknown, "", err
+	}
+
+	outcome, message := parseSubmitResponse(page)
+	return outcome, message, nil
+}
+
+// parseSubmitResponse reads the <article> of an answer page, which holds the verdict.
+func parseSubmitResponse(page []byte) (submitOutcome, string) {
+	text := string(page)
+	if match := articleRegex.FindStringSubmatch(text); match != nil {
+		text = match[1]
+	}
+
+	message := strings.Join(strings.Fields(html.UnescapeString(htmlTagRegex.ReplaceAllString(text, ""))), " ")
+
+	switch {
+	case strings.Contains(message, "That's the right answer"):
+		return submitCorrect, message
+	case strings.Contains(message, "That's not the right answer"):
+		return submitWrong, message
+	case strings.Contains(message, "You gave an answer too recently"):
+		return submitTooSoon, message
+	case strings.Contains(message, "Did you already complete it"):
+		return submitAlreadyDone, message
+	default:
+		return submitUnknown, message
+	}
+}
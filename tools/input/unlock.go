@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+	_ "time/tzdata"
+)
+
+const (
+	unlockRetryAttempts = 10
+	unlockRetryDelay    = 2 * time.Second
+)
+
+// clock abstracts time so the unlock logic can be driven by tests.
+type clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// puzzleTimeZone is where puzzles unlock at midnight.
+func puzzleTimeZone() *time.Location {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		panic(fmt.Errorf("failed to load puzzle time zone: %w", err))
+	}
+	return location
+}
+
+// puzzleUnlock returns the moment a day unlocks: midnight in US Eastern time.
+func puzzleUnlock(year, day int) time.Time {
+	return time.Date(year, time.December, day, 0, 0, 0, 0, puzzleTimeZone())
+}
+
+// lastPuzzleDay is 25 up to 2024; from 2025 on the event runs for 12 days.
+func lastPuzzleDay(year int) int {
+	if year < 2025 {
+		return 25
+	}
+	return 12
+}
+
+// nextUnlock finds the first puzzle that unlocks strictly after now.
+func nextUnlock(now time.Time) (year, day int) {
+	local := now.In(puzzleTimeZone())
+	year = local.Year()
+
+	if local.Month() != time.December {
+		return year, 1
+	}
+
+	day = local.Day() + 1
+	if day > lastPuzzleDay(year) {
+		return year + 1, 1
+	}
+
+	return year, day
+}
+
+// waitForUnlock blocks until the unlock moment, printing a countdown once per second.
+func waitForUnlock(clk clock, out io.Writer, unlock time.Time) {
+	for {
+		remaining := unlock.Sub(clk.Now())
+		if remaining <= 0 {
+			fmt.Fprintln(out, "\rUnlocked!                    ")
+			return
+		}
+
+		fmt.Fprintf(out, "\rUnlocks in %s   ", formatSplit(remaining.Round(time.Second)))
+		clk.Sleep(min(remaining, time.Second))
+	}
+}
+
+// fetchWithRetry repeats the fetch while the site still answers 404, which it does briefly around unlock.
+func fetchWithRetry(clk clock, out io.Writer, fetch func() ([]byte, error)) ([]byte, error) {
+	var err error
+
+	for attempt := 1; attempt <= unlockRetryAttempts; attempt++ {
+		var data []byte
+		data, err = fetch()
+		if err == nil {
+			return data, nil
+		}
+
+		var statusErr *statusError
+		if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+			return nil, err
+		}
+
+		if attempt == unlockRetryAttempts {
+			break
+		}
+
+		fmt.Fprintf(out, "Not available yet (attempt %d/%d), retrying in %s...\n", attempt, unlockRetryAttempts, unlockRetryDelay)
+		clk.Sleep(unlockRetryDelay)
+	}
+
+	return nil, fmt.Errorf("gave up after %d attempts: %w", unlockRetryAttempts, err)
+}
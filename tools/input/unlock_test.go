@@ -0,0 +1,144 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock advances only when slept on.
+type fakeClock struct {
+	now   time.Time
+	slept time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.now = c.now.Add(d)
+	c.slept += d
+}
+
+func TestNextUnlock(t *testing.T) {
+	eastern := puzzleTimeZone()
+
+	cases := []struct {
+		name      string
+		now       time.Time
+		year, day int
+	}{
+		{"before event", time.Date(2025, time.November, 30, 12, 0, 0, 0, eastern), 2025, 1},
+		{"during event", time.Date(2025, time.December, 3, 23, 59, 0, 0, eastern), 2025, 4},
+		{"just after unlock", time.Date(2025, time.December, 4, 0, 0, 1, 0, eastern), 2025, 5},
+		{"after last day", time.Date(2025, time.December, 12, 8, 0, 0, 0, eastern), 2026, 1},
+		{"25 day event", time.Date(2024, time.December, 12, 8, 0, 0, 0, eastern), 2024, 13},
+		// 04:30 UTC on the 5th is still the evening of the 4th in New York.
+		{"utc ahead of eastern", time.Date(2025, time.December, 5, 4, 30, 0, 0, time.UTC), 2025, 5},
+	}
+
+	for _, c := range cases {
+		year, day := nextUnlock(c.now)
+		if year != c.year || day != c.day {
+			t.Errorf("%s: expected %d/%d, got %d/%d", c.name, c.year, c.day, year, day)
+		}
+	}
+}
+
+func TestPuzzleUnlockIsMidnightEastern(t *testing.T) {
+	unlock := puzzleUnlock(2025, 1).UTC()
+	expected := time.Date(2025, time.December, 1, 5, 0, 0, 0, time.UTC)
+
+	if !unlock.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, unlock)
+	}
+}
+
+func TestWaitForUnlock(t *testing.T) {
+	unlock := puzzleUnlock(2025, 2)
+	clk := &fakeClock{now: unlock.Add(-90*time.Second - 250*time.Millisecond)}
+
+	waitForUnlock(clk, io.Discard, unlock)
+
+	if clk.now.Before(unlock) {
+		t.Errorf("returned before unlock: %s", clk.now)
+	}
+	if clk.now.After(unlock) {
+		t.Errorf("overslept past unlock: %s", clk.now.Sub(unlock))
+	}
+}
+
+func TestFetchWithRetry(t *testing.T) {
+	t.Run("retries_404", func(t *testing.T) {
+		clk := &fakeClock{now: time.Now()}
+		calls := 0
+
+		data, err := fetchWithRetry(clk, io.Discard, func() ([]byte, error) {
+			calls++
+			if calls < 3 {
+				return nil, &statusError{StatusCode: http.StatusNotFound}
+			}
+			return []byte("input"), nil
+		})
+
+		if err != nil || string(data) != "input" {
+			t.Fatalf("expected input, got %q (%v)", data, err)
+		}
+		if calls != 3 || clk.slept != 2*unlockRetryDelay {
+			t.Errorf("expected 3 calls and 2 delays, got %d calls and %s slept", calls, clk.slept)
+		}
+	})
+
+	t.Run("stops_on_other_errors", func(t *testing.T) {
+		clk := &fakeClock{now: time.Now()}
+		calls := 0
+
+		_, err := fetchWithRetry(clk, io.Discard, func() ([]byte, error) {
+			calls++
+			return nil, &statusError{StatusCode: http.StatusBadRequest}
+		})
+
+		var statusErr *statusError
+		if !errors.As(err, &statusErr) || calls != 1 {
+			t.Errorf("expected a single failing call, got %d calls (%v)", calls, err)
+		}
+	})
+
+	t.Run("gives_up", func(t *testing.T) {
+		clk := &fakeClock{now: time.Now()}
+
+		var out strings.Builder
+		calls := 0
+
+		_, err := fetchWithRetry(clk, &out, func() ([]byte, error) {
+			calls++
+			return nil, &statusError{StatusCode: http.StatusNotFound}
+		})
+
+		if err == nil {
+			t.Error("expected an error after exhausting retries")
+		}
+		// No wait and no retry message follow the last attempt.
+		if calls != unlockRetryAttempts || clk.slept != time.Duration(unlockRetryAttempts-1)*unlockRetryDelay {
+			t.Errorf("expected %d calls and %d delays, got %d calls and %s slept", unlockRetryAttempts, unlockRetryAttempts-1, calls, clk.slept)
+		}
+		if retries := strings.Count(out.String(), "retrying"); retries != unlockRetryAttempts-1 {
+			t.Errorf("expected %d retry messages, got %d", unlockRetryAttempts-1, retries)
+		}
+	})
+}
+
+func TestExtractExamples(t *testing.T) {
+	page := []byte(`<p>For example:</p><pre><code>1 &lt; 2
+<em>3</em>
+</code></pre><p>More</p><pre><code>x</code></pre>`)
+
+	examples := extractExamples(page)
+	if len(examples) != 2 || examples[0] != "1 < 2\n3\n" || examples[1] != "x" {
+		t.Errorf("unexpected examples: %q", examples)
+	}
+}